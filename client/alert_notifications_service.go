@@ -0,0 +1,152 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// AlertNotificationsService communicates with notification channel methods of
+// the legacy alerting of the Grafana API.
+type AlertNotificationsService struct {
+	client *Client
+}
+
+// NewAlertNotificationsService returns a new AlertNotificationsService.
+func NewAlertNotificationsService(client *Client) *AlertNotificationsService {
+	return &AlertNotificationsService{
+		client: client,
+	}
+}
+
+// ErrAlertNotificationNotFound represents an error if notification channel
+// not found.
+var ErrAlertNotificationNotFound = errors.New("Alert notification not found")
+
+// List fetches all notification channels.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#get-all-notification-channels
+func (s *AlertNotificationsService) List(ctx context.Context) ([]*grafana.AlertNotification, error) {
+	var notifications []*grafana.AlertNotification
+	if err := s.do(ctx, "GET", "/api/alert-notifications", nil, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// GetByID fetches notification channel with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#get-notification-channel-by-id
+func (s *AlertNotificationsService) GetByID(ctx context.Context, id grafana.AlertNotificationID) (*grafana.AlertNotification, error) {
+	return s.get(ctx, fmt.Sprintf("/api/alert-notifications/%d", id))
+}
+
+// GetByUID fetches notification channel with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#get-notification-channel-by-uid
+func (s *AlertNotificationsService) GetByUID(ctx context.Context, uid string) (*grafana.AlertNotification, error) {
+	return s.get(ctx, "/api/alert-notifications/uid/"+url.PathEscape(uid))
+}
+
+func (s *AlertNotificationsService) get(ctx context.Context, u string) (*grafana.AlertNotification, error) {
+	var notification grafana.AlertNotification
+	if err := s.do(ctx, "GET", u, nil, &notification); err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// Create creates a new notification channel and sets ID and UID of given one.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#create-notification-channel
+func (s *AlertNotificationsService) Create(ctx context.Context, notification *grafana.AlertNotification) error {
+	var created grafana.AlertNotification
+	if err := s.do(ctx, "POST", "/api/alert-notifications", notification, &created); err != nil {
+		return err
+	}
+
+	notification.ID = created.ID
+	notification.UID = created.UID
+	return nil
+}
+
+// Update replaces notification channel with id of given one.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#update-notification-channel-by-id
+func (s *AlertNotificationsService) Update(ctx context.Context, notification *grafana.AlertNotification) error {
+	u := fmt.Sprintf("/api/alert-notifications/%d", notification.ID)
+	return s.do(ctx, "PUT", u, notification, nil)
+}
+
+// UpdateByUID replaces notification channel with uid of given one.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#update-notification-channel-by-uid
+func (s *AlertNotificationsService) UpdateByUID(ctx context.Context, notification *grafana.AlertNotification) error {
+	if notification.UID == "" {
+		return errors.New("UID cannot be empty")
+	}
+
+	u := "/api/alert-notifications/uid/" + url.PathEscape(notification.UID)
+	return s.do(ctx, "PUT", u, notification, nil)
+}
+
+// Delete deletes notification channel with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#delete-notification-channel-by-id
+func (s *AlertNotificationsService) Delete(ctx context.Context, id grafana.AlertNotificationID) error {
+	u := fmt.Sprintf("/api/alert-notifications/%d", id)
+	return s.do(ctx, "DELETE", u, nil, nil)
+}
+
+// DeleteByUID deletes notification channel with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#delete-notification-channel-by-uid
+func (s *AlertNotificationsService) DeleteByUID(ctx context.Context, uid string) error {
+	u := "/api/alert-notifications/uid/" + url.PathEscape(uid)
+	return s.do(ctx, "DELETE", u, nil, nil)
+}
+
+// Test sends a test notification with type and settings of given channel. The
+// channel doesn't need to be saved.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/alerting_notification_channels/#test-notification-channel
+func (s *AlertNotificationsService) Test(ctx context.Context, notification *grafana.AlertNotification) error {
+	return s.do(ctx, "POST", "/api/alert-notifications/test", notification, nil)
+}
+
+// do sends an API request and decodes the response into v if it's not nil.
+// It returns ErrAlertNotificationNotFound if the API responds with 404.
+func (s *AlertNotificationsService) do(ctx context.Context, method, u string, body, v interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, v); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrAlertNotificationNotFound
+		}
+		return err
+	}
+
+	return nil
+}
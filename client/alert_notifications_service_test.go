@@ -0,0 +1,96 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestAlertNotificationsService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/alert-notifications", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"ops","type":"slack","isDefault":false,"sendReminder":true,"frequency":"1h","disableResolveMessage":false,"settings":{"recipient":"#ops","url":"https://hooks.slack.com/services/x"}}`+"\n")
+		fmt.Fprint(w, `{"id": 3, "uid": "ops-slack", "name": "ops", "type": "slack", "settings": {"recipient": "#ops"}, "secureFields": {"url": true}}`)
+	})
+
+	notification := grafana.NewAlertNotification("ops", &grafana.SlackNotificationSettings{URL: "https://hooks.slack.com/services/x", Recipient: "#ops"})
+	notification.SendReminder = true
+	notification.Frequency = "1h"
+	if err := client.AlertNotifications.Create(context.Background(), notification); err != nil {
+		t.Fatalf("AlertNotifications.Create returned error: %v", err)
+	}
+	if notification.ID != 3 || notification.UID != "ops-slack" {
+		t.Errorf("AlertNotifications.Create set id %d and uid %q, want 3 and ops-slack", notification.ID, notification.UID)
+	}
+}
+
+func TestAlertNotificationsService_GetByUID(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/alert-notifications/uid/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Path != "/api/alert-notifications/uid/hook" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Alert notification not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "uid": "hook", "name": "hook", "type": "webhook", "settings": {"url": "http://example.com", "httpMethod": "PUT"}}`)
+	})
+
+	ctx := context.Background()
+	notification, err := client.AlertNotifications.GetByUID(ctx, "hook")
+	if err != nil {
+		t.Fatalf("AlertNotifications.GetByUID returned error: %v", err)
+	}
+	if settings, ok := notification.Settings.(*grafana.WebhookNotificationSettings); !ok || settings.HTTPMethod != "PUT" {
+		t.Errorf("AlertNotifications.GetByUID returned settings %#v", notification.Settings)
+	}
+
+	if _, err := client.AlertNotifications.GetByUID(ctx, "unknown"); err != ErrAlertNotificationNotFound {
+		t.Errorf("AlertNotifications.GetByUID returned error %v, want %v", err, ErrAlertNotificationNotFound)
+	}
+}
+
+func TestAlertNotificationsService_Test(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/alert-notifications/test", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"ops","type":"email","isDefault":false,"sendReminder":false,"disableResolveMessage":false,"settings":{"addresses":"ops@example.com","singleEmail":false}}`+"\n")
+		fmt.Fprint(w, `{"message": "Test notification sent"}`)
+	})
+
+	notification := grafana.NewAlertNotification("ops", &grafana.EmailNotificationSettings{Addresses: grafana.EmailAddresses{"ops@example.com"}})
+	if err := client.AlertNotifications.Test(context.Background(), notification); err != nil {
+		t.Errorf("AlertNotifications.Test returned error: %v", err)
+	}
+}
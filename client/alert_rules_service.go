@@ -0,0 +1,167 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// AlertRulesService communicates with alert rule provisioning methods of the
+// Grafana API.
+type AlertRulesService struct {
+	client *Client
+}
+
+// NewAlertRulesService returns a new AlertRulesService.
+func NewAlertRulesService(client *Client) *AlertRulesService {
+	return &AlertRulesService{
+		client: client,
+	}
+}
+
+// ErrAlertRuleNotFound represents an error if alert rule not found.
+var ErrAlertRuleNotFound = errors.New("Alert rule not found")
+
+// ErrAlertRuleGroupNotFound represents an error if alert rule group not found.
+var ErrAlertRuleGroupNotFound = errors.New("Alert rule group not found")
+
+// ProvisioningOptions specifies the optional parameters to methods changing
+// provisioned resources of alerting.
+type ProvisioningOptions struct {
+	// DisableProvenance allows to edit the changed resource in Grafana UI.
+	// Otherwise it can be changed only with the API.
+	DisableProvenance bool
+}
+
+// List fetches all alert rules.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-alert-rules
+func (s *AlertRulesService) List(ctx context.Context) ([]*grafana.AlertRule, error) {
+	var rules []*grafana.AlertRule
+	if err := doProvisioning(ctx, s.client, "GET", "/api/v1/provisioning/alert-rules", nil, nil, &rules, ErrAlertRuleNotFound); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Get fetches alert rule by given uid.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-alert-rule
+func (s *AlertRulesService) Get(ctx context.Context, uid string) (*grafana.AlertRule, error) {
+	var rule grafana.AlertRule
+	if err := doProvisioning(ctx, s.client, "GET", alertRuleURL(uid), nil, nil, &rule, ErrAlertRuleNotFound); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// Create creates a new alert rule and returns it as it's saved.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-post-alert-rule
+func (s *AlertRulesService) Create(ctx context.Context, rule *grafana.AlertRule, opt *ProvisioningOptions) (*grafana.AlertRule, error) {
+	var created grafana.AlertRule
+	if err := doProvisioning(ctx, s.client, "POST", "/api/v1/provisioning/alert-rules", opt, rule, &created, ErrAlertRuleNotFound); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update replaces alert rule with uid of given one and returns it as it's
+// saved.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-put-alert-rule
+func (s *AlertRulesService) Update(ctx context.Context, rule *grafana.AlertRule, opt *ProvisioningOptions) (*grafana.AlertRule, error) {
+	if rule.UID == "" {
+		return nil, errors.New("UID cannot be empty")
+	}
+
+	var updated grafana.AlertRule
+	if err := doProvisioning(ctx, s.client, "PUT", alertRuleURL(rule.UID), opt, rule, &updated, ErrAlertRuleNotFound); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// Delete deletes alert rule with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-delete-alert-rule
+func (s *AlertRulesService) Delete(ctx context.Context, uid string, opt *ProvisioningOptions) error {
+	return doProvisioning(ctx, s.client, "DELETE", alertRuleURL(uid), opt, nil, nil, ErrAlertRuleNotFound)
+}
+
+// Group fetches rule group with given title in folder with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-alert-rule-group
+func (s *AlertRulesService) Group(ctx context.Context, folderUID, title string) (*grafana.AlertRuleGroup, error) {
+	var group grafana.AlertRuleGroup
+	if err := doProvisioning(ctx, s.client, "GET", alertRuleGroupURL(folderUID, title), nil, nil, &group, ErrAlertRuleGroupNotFound); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// UpdateGroup replaces rules and interval of given rule group and returns it as
+// it's saved. Rules of the group missing in it are deleted.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-put-alert-rule-group
+func (s *AlertRulesService) UpdateGroup(ctx context.Context, group *grafana.AlertRuleGroup, opt *ProvisioningOptions) (*grafana.AlertRuleGroup, error) {
+	var updated grafana.AlertRuleGroup
+	u := alertRuleGroupURL(group.FolderUID, group.Title)
+	if err := doProvisioning(ctx, s.client, "PUT", u, opt, group, &updated, ErrAlertRuleGroupNotFound); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func alertRuleURL(uid string) string {
+	return "/api/v1/provisioning/alert-rules/" + url.PathEscape(uid)
+}
+
+func alertRuleGroupURL(folderUID, title string) string {
+	return fmt.Sprintf("/api/v1/provisioning/folder/%s/rule-groups/%s", url.PathEscape(folderUID), url.PathEscape(title))
+}
+
+// doProvisioning sends an API request to alerting provisioning endpoint and
+// decodes the response into v if it's not nil. It returns notFound if it's not
+// nil and the API responds with 404.
+func doProvisioning(ctx context.Context, c *Client, method, u string, opt *ProvisioningOptions, body, v interface{}, notFound error) error {
+	req, err := c.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	if opt != nil && opt.DisableProvenance {
+		req.Header.Set("X-Disable-Provenance", "true")
+	}
+
+	if resp, err := c.Do(req, v); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound && notFound != nil {
+			return notFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestAlertRulesService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/v1/provisioning/alert-rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.Header.Get("X-Disable-Provenance"); got != "true" {
+			t.Errorf("X-Disable-Provenance header: %q, want true", got)
+		}
+		testBody(t, r, `{"orgID":0,"folderUID":"slo","ruleGroup":"latency","title":"Latency SLO","condition":"B",`+
+			`"data":[{"refId":"A","queryType":"","relativeTimeRange":{"from":600,"to":0},"datasourceUid":"prometheus","model":{"expr":"up"}},`+
+			`{"refId":"B","queryType":"","relativeTimeRange":{"from":0,"to":0},"datasourceUid":"__expr__","model":{"expression":"$A == 0","type":"math"}}],`+
+			`"noDataState":"NoData","execErrState":"Error","for":"1m","annotations":{},"labels":{},"isPaused":false}`+"\n")
+		fmt.Fprint(w, `{"id": 1, "uid": "generated", "orgID": 1, "folderUID": "slo", "ruleGroup": "latency", "title": "Latency SLO"}`)
+	})
+
+	rule := grafana.NewAlertRule("Latency SLO", "slo", "latency")
+	rule.Condition = "B"
+	rule.Data = []*grafana.AlertRuleQuery{
+		grafana.NewAlertRuleQuery("A", "prometheus", 10*time.Minute, map[string]string{"expr": "up"}),
+		grafana.NewExpressionQuery("B", &grafana.MathExpression{Expression: "$A == 0"}),
+	}
+	created, err := client.AlertRules.Create(context.Background(), rule, &ProvisioningOptions{DisableProvenance: true})
+	if err != nil {
+		t.Fatalf("AlertRules.Create returned error: %v", err)
+	}
+	if created.UID != "generated" || created.ID != 1 {
+		t.Errorf("AlertRules.Create returned rule %+v, want one with uid generated and id 1", created)
+	}
+}
+
+func TestAlertRulesService_Get_NotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	if _, err := client.AlertRules.Get(context.Background(), "unknown"); err != ErrAlertRuleNotFound {
+		t.Errorf("AlertRules.Get returned error %v, want %v", err, ErrAlertRuleNotFound)
+	}
+}
+
+func TestAlertRulesService_UpdateGroup(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/v1/provisioning/folder/slo/rule-groups/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		if got, want := r.URL.EscapedPath(), "/api/v1/provisioning/folder/slo/rule-groups/api%20latency"; got != want {
+			t.Errorf("Request path: %s, want %s", got, want)
+		}
+		if got := r.Header.Get("X-Disable-Provenance"); got != "" {
+			t.Errorf("X-Disable-Provenance header: %q, want none", got)
+		}
+		testBody(t, r, `{"title":"api latency","folderUid":"slo","rules":[],"interval":120}`+"\n")
+		fmt.Fprint(w, `{"title": "api latency", "folderUid": "slo", "interval": 120, "rules": []}`)
+	})
+
+	group := &grafana.AlertRuleGroup{Title: "api latency", FolderUID: "slo", Interval: 2 * time.Minute, Rules: []*grafana.AlertRule{}}
+	updated, err := client.AlertRules.UpdateGroup(context.Background(), group, nil)
+	if err != nil {
+		t.Fatalf("AlertRules.UpdateGroup returned error: %v", err)
+	}
+	if updated.Interval != 2*time.Minute {
+		t.Errorf("AlertRules.UpdateGroup returned interval %s, want 2m", updated.Interval)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// AnnotationsService communicates with annotation methods of the Grafana API.
+type AnnotationsService struct {
+	client *Client
+}
+
+// NewAnnotationsService returns a new AnnotationsService.
+func NewAnnotationsService(client *Client) *AnnotationsService {
+	return &AnnotationsService{
+		client: client,
+	}
+}
+
+// ErrAnnotationNotFound represents an error if annotation not found.
+var ErrAnnotationNotFound = errors.New("Annotation not found")
+
+type annotationType string
+
+// Types of annotations found by AnnotationsService.Find.
+const (
+	AlertAnnotationType annotationType = "alert"
+	EventAnnotationType annotationType = "annotation" // created by users or with the API
+)
+
+// AnnotationFindOptions specifies the optional parameters to the
+// AnnotationsService.Find method.
+type AnnotationFindOptions struct {
+	From         time.Time           `url:"from,omitempty,unixmilli"`
+	To           time.Time           `url:"to,omitempty,unixmilli"`
+	DashboardID  grafana.DashboardID `url:"dashboardId,omitempty"`
+	DashboardUID string              `url:"dashboardUID,omitempty"`
+	PanelID      uint                `url:"panelId,omitempty"`
+	AlertID      uint64              `url:"alertId,omitempty"`
+	UserID       grafana.UserID      `url:"userId,omitempty"`
+	Type         annotationType      `url:"type,omitempty"`
+	Tags         []string            `url:"tags,omitempty"`
+	MatchAny     bool                `url:"matchAny,omitempty"` // match any of tags instead of all of them
+	Limit        int                 `url:"limit,omitempty"`
+}
+
+// Find fetches annotations matching given criteria, the newest first.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/annotations/#find-annotations
+func (s *AnnotationsService) Find(ctx context.Context, opt *AnnotationFindOptions) ([]*grafana.AnnotationEvent, error) {
+	u, err := addOptions("/api/annotations", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []*grafana.AnnotationEvent
+	if err := s.do(ctx, "GET", u, nil, &annotations); err != nil {
+		return nil, err
+	}
+
+	return annotations, nil
+}
+
+// annotationCreateResponse is a response of API on annotation create.
+type annotationCreateResponse struct {
+	ID      grafana.AnnotationEventID `json:"id"`
+	Message string                    `json:"message"`
+}
+
+// Create creates a new annotation. ID of the created annotation is set to
+// annotation.ID. The annotation is global unless it has DashboardUID.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/annotations/#create-annotation
+func (s *AnnotationsService) Create(ctx context.Context, annotation *grafana.AnnotationEvent) error {
+	var result annotationCreateResponse
+	if err := s.do(ctx, "POST", "/api/annotations", annotation, &result); err != nil {
+		return err
+	}
+
+	annotation.ID = result.ID
+	return nil
+}
+
+// GraphiteAnnotation is an annotation in Graphite format.
+type GraphiteAnnotation struct {
+	What string
+	When time.Time // current time if zero
+	Tags []string
+	Data string
+}
+
+// CreateGraphite creates a new global annotation given in Graphite format and
+// returns its id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/annotations/#create-annotation-in-graphite-format
+func (s *AnnotationsService) CreateGraphite(ctx context.Context, annotation *GraphiteAnnotation) (grafana.AnnotationEventID, error) {
+	body := struct {
+		What string   `json:"what"`
+		When int64    `json:"when,omitempty"` // seconds since epoch
+		Tags []string `json:"tags"`
+		Data string   `json:"data,omitempty"`
+	}{What: annotation.What, Tags: annotation.Tags, Data: annotation.Data}
+	if !annotation.When.IsZero() {
+		body.When = annotation.When.Unix()
+	}
+
+	var result annotationCreateResponse
+	if err := s.do(ctx, "POST", "/api/annotations/graphite", body, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// Update replaces all fields of given annotation.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/annotations/#update-annotation
+func (s *AnnotationsService) Update(ctx context.Context, annotation *grafana.AnnotationEvent) error {
+	u := fmt.Sprintf("/api/annotations/%d", annotation.ID)
+	return s.do(ctx, "PUT", u, annotation, nil)
+}
+
+// AnnotationPatch contains fields of annotation to change by
+// AnnotationsService.Patch. Empty fields are left intact.
+type AnnotationPatch struct {
+	Text    string
+	Tags    []string // nil keeps tags, empty slice removes them
+	Time    time.Time
+	TimeEnd time.Time
+}
+
+// Patch changes given fields of annotation with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/annotations/#patch-annotation
+func (s *AnnotationsService) Patch(ctx context.Context, id grafana.AnnotationEventID, patch *AnnotationPatch) error {
+	body := struct {
+		Text    string   `json:"text,omitempty"`
+		Tags    []string `json:"tags"`
+		Time    int64    `json:"time,omitempty"`
+		TimeEnd int64    `json:"timeEnd,omitempty"`
+	}{Text: patch.Text, Tags: patch.Tags}
+	if !patch.Time.IsZero() {
+		body.Time = patch.Time.UnixNano() / int64(time.Millisecond)
+	}
+	if !patch.TimeEnd.IsZero() {
+		body.TimeEnd = patch.TimeEnd.UnixNano() / int64(time.Millisecond)
+	}
+
+	u := fmt.Sprintf("/api/annotations/%d", id)
+	return s.do(ctx, "PATCH", u, body, nil)
+}
+
+// Delete deletes annotation with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/annotations/#delete-annotation-by-id
+func (s *AnnotationsService) Delete(ctx context.Context, id grafana.AnnotationEventID) error {
+	u := fmt.Sprintf("/api/annotations/%d", id)
+	return s.do(ctx, "DELETE", u, nil, nil)
+}
+
+// Tags fetches tags of annotations starting with given prefix, up to limit of
+// them. All tags are fetched if prefix is empty.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/annotations/#find-annotations-tags
+func (s *AnnotationsService) Tags(ctx context.Context, prefix string, limit int) ([]*grafana.AnnotationTag, error) {
+	opt := struct {
+		Tag   string `url:"tag,omitempty"`
+		Limit int    `url:"limit,omitempty"`
+	}{prefix, limit}
+	u, err := addOptions("/api/annotations/tags", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result struct {
+			Tags []*grafana.AnnotationTag `json:"tags"`
+		} `json:"result"`
+	}
+	if err := s.do(ctx, "GET", u, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result.Tags, nil
+}
+
+// do sends an API request and decodes the response into v if it's not nil.
+// It returns ErrAnnotationNotFound if the API responds with 404.
+func (s *AnnotationsService) do(ctx context.Context, method, u string, body, v interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, v); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrAnnotationNotFound
+		}
+		return err
+	}
+
+	return nil
+}
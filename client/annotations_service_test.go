@@ -0,0 +1,168 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestAnnotationsService_Find(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/annotations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, url.Values{
+			"from":         {"1507266395000"},
+			"to":           {"1507270000000"},
+			"dashboardUID": {"uid"},
+			"panelId":      {"2"},
+			"type":         {"annotation"},
+			"tags":         {"deploy", "prod"},
+			"matchAny":     {"true"},
+			"limit":        {"10"},
+		})
+		fmt.Fprint(w, `[{"id": 1, "dashboardUID": "uid", "panelId": 2, "text": "Deployed", "tags": ["deploy"], "time": 1507266395000, "timeEnd": 1507266395000}]`)
+	})
+
+	opt := &AnnotationFindOptions{
+		From:         time.Unix(1507266395, 0),
+		To:           time.Unix(1507270000, 0),
+		DashboardUID: "uid",
+		PanelID:      2,
+		Type:         EventAnnotationType,
+		Tags:         []string{"deploy", "prod"},
+		MatchAny:     true,
+		Limit:        10,
+	}
+	annotations, err := client.Annotations.Find(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("Annotations.Find returned error: %v", err)
+	}
+
+	at := time.Date(2017, 10, 6, 5, 6, 35, 0, time.UTC)
+	want := []*grafana.AnnotationEvent{{
+		ID: 1, DashboardUID: "uid", PanelID: 2, Text: "Deployed", Tags: []string{"deploy"}, Time: at, TimeEnd: at,
+	}}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("Annotations.Find returned %+v, want %+v", annotations, want)
+	}
+	if annotations[0].IsRegion() {
+		t.Errorf("Annotations.Find returned region annotation, want point one")
+	}
+}
+
+func TestAnnotationsService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/annotations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"dashboardUID":"uid","text":"Deploy","tags":["deploy"],"time":1507266395000,"timeEnd":1507266455000}`+"\n")
+		fmt.Fprint(w, `{"id": 5, "message": "Annotation added"}`)
+	})
+
+	start := time.Unix(1507266395, 0)
+	annotation := &grafana.AnnotationEvent{
+		DashboardUID: "uid",
+		Text:         "Deploy",
+		Tags:         []string{"deploy"},
+		Time:         start,
+		TimeEnd:      start.Add(time.Minute),
+	}
+	if err := client.Annotations.Create(context.Background(), annotation); err != nil {
+		t.Fatalf("Annotations.Create returned error: %v", err)
+	}
+	if annotation.ID != 5 {
+		t.Errorf("Annotations.Create set ID %d, want 5", annotation.ID)
+	}
+}
+
+func TestAnnotationsService_CreateGraphite(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/annotations/graphite", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"what":"Event","when":1507266395,"tags":["deploy"],"data":"Data"}`+"\n")
+		fmt.Fprint(w, `{"id": 6, "message": "Graphite annotation added"}`)
+	})
+
+	annotation := &GraphiteAnnotation{What: "Event", When: time.Unix(1507266395, 0), Tags: []string{"deploy"}, Data: "Data"}
+	id, err := client.Annotations.CreateGraphite(context.Background(), annotation)
+	if err != nil {
+		t.Fatalf("Annotations.CreateGraphite returned error: %v", err)
+	}
+	if id != 6 {
+		t.Errorf("Annotations.CreateGraphite returned id %d, want 6", id)
+	}
+}
+
+func TestAnnotationsService_Patch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/annotations/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"tags":[],"timeEnd":1507266455000}`+"\n")
+		fmt.Fprint(w, `{"message": "Annotation patched"}`)
+	})
+
+	patch := &AnnotationPatch{Tags: []string{}, TimeEnd: time.Unix(1507266455, 0)}
+	if err := client.Annotations.Patch(context.Background(), 5, patch); err != nil {
+		t.Errorf("Annotations.Patch returned error: %v", err)
+	}
+	if err := client.Annotations.Patch(context.Background(), 6, patch); err != ErrAnnotationNotFound {
+		t.Errorf("Annotations.Patch returned error %v, want %v", err, ErrAnnotationNotFound)
+	}
+}
+
+func TestAnnotationsService_Tags(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/annotations/tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, url.Values{"tag": {"dep"}, "limit": {"5"}})
+		fmt.Fprint(w, `{"result": {"tags": [{"tag": "deploy", "count": 12}]}}`)
+	})
+
+	tags, err := client.Annotations.Tags(context.Background(), "dep", 5)
+	if err != nil {
+		t.Fatalf("Annotations.Tags returned error: %v", err)
+	}
+	want := []*grafana.AnnotationTag{{Tag: "deploy", Count: 12}}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("Annotations.Tags returned %+v, want %+v", tags, want)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// ContactPointsService communicates with contact point provisioning methods of
+// the Grafana API.
+type ContactPointsService struct {
+	client *Client
+}
+
+// NewContactPointsService returns a new ContactPointsService.
+func NewContactPointsService(client *Client) *ContactPointsService {
+	return &ContactPointsService{
+		client: client,
+	}
+}
+
+// ErrContactPointNotFound represents an error if contact point not found.
+var ErrContactPointNotFound = errors.New("Contact point not found")
+
+// List fetches contact points with given name or all of them if name is empty.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-contactpoints
+func (s *ContactPointsService) List(ctx context.Context, name string) ([]*grafana.ContactPoint, error) {
+	opt := struct {
+		Name string `url:"name,omitempty"`
+	}{name}
+	u, err := addOptions("/api/v1/provisioning/contact-points", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []*grafana.ContactPoint
+	if err := doProvisioning(ctx, s.client, "GET", u, nil, nil, &points, ErrContactPointNotFound); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// Create creates a new contact point and returns it as it's saved.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-post-contactpoints
+func (s *ContactPointsService) Create(ctx context.Context, point *grafana.ContactPoint, opt *ProvisioningOptions) (*grafana.ContactPoint, error) {
+	var created grafana.ContactPoint
+	if err := doProvisioning(ctx, s.client, "POST", "/api/v1/provisioning/contact-points", opt, point, &created, ErrContactPointNotFound); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update replaces contact point with uid of given one.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-put-contactpoint
+func (s *ContactPointsService) Update(ctx context.Context, point *grafana.ContactPoint, opt *ProvisioningOptions) error {
+	if point.UID == "" {
+		return errors.New("UID cannot be empty")
+	}
+
+	u := "/api/v1/provisioning/contact-points/" + url.PathEscape(point.UID)
+	return doProvisioning(ctx, s.client, "PUT", u, opt, point, nil, ErrContactPointNotFound)
+}
+
+// Delete deletes contact point with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-delete-contactpoints
+func (s *ContactPointsService) Delete(ctx context.Context, uid string) error {
+	u := "/api/v1/provisioning/contact-points/" + url.PathEscape(uid)
+	return doProvisioning(ctx, s.client, "DELETE", u, nil, nil, nil, ErrContactPointNotFound)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestContactPointsService_List(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/v1/provisioning/contact-points", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, url.Values{"name": {"ops"}})
+		fmt.Fprint(w, `[{"uid": "hook", "name": "ops", "type": "webhook", "settings": {"url": "http://example.com/alerts", "httpMethod": "POST"}}]`)
+	})
+
+	points, err := client.ContactPoints.List(context.Background(), "ops")
+	if err != nil {
+		t.Fatalf("ContactPoints.List returned error: %v", err)
+	}
+
+	want := grafana.NewContactPoint("ops", &grafana.WebhookSettings{URL: "http://example.com/alerts", HTTPMethod: "POST"})
+	want.UID = "hook"
+	if len(points) != 1 || points[0].UID != want.UID || points[0].Name != want.Name || points[0].Type != want.Type ||
+		!reflect.DeepEqual(points[0].Settings, want.Settings) {
+		t.Errorf("ContactPoints.List returned %+v, want %+v", points, want)
+	}
+}
+
+func TestContactPointsService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/v1/provisioning/contact-points", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"oncall","type":"opsgenie","disableResolveMessage":false,"settings":{"apiKey":"key","autoClose":true,"overridePriority":false}}`+"\n")
+		fmt.Fprint(w, `{"uid": "generated", "name": "oncall", "type": "opsgenie", "settings": {"apiKey": "[REDACTED]", "autoClose": true}}`)
+	})
+
+	point := grafana.NewContactPoint("oncall", &grafana.OpsgenieSettings{APIKey: "key", AutoClose: true})
+	created, err := client.ContactPoints.Create(context.Background(), point, nil)
+	if err != nil {
+		t.Fatalf("ContactPoints.Create returned error: %v", err)
+	}
+	if created.UID != "generated" {
+		t.Errorf("ContactPoints.Create returned uid %q, want generated", created.UID)
+	}
+	if _, ok := created.Settings.(*grafana.OpsgenieSettings); !ok {
+		t.Errorf("ContactPoints.Create returned settings of type %T, want *grafana.OpsgenieSettings", created.Settings)
+	}
+}
@@ -14,10 +14,13 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/utilitywarehouse/go-grafana/grafana"
 )
@@ -37,36 +40,35 @@ func NewDashboardsService(client *Client) *DashboardsService {
 // ErrDashboardNotFound represents an error if dashboard not found.
 var ErrDashboardNotFound = errors.New("Dashboard not found")
 
+// ErrDashboardVersionNotFound represents an error if dashboard's version not found.
+var ErrDashboardVersionNotFound = errors.New("Dashboard version not found")
+
+// DashboardGetOptions specifies the optional parameters to the
+// DashboardsService.Get and DashboardsService.GetByUID methods.
+type DashboardGetOptions struct {
+	// SchemaVersion is a version of dashboard's schema that fetched dashboard is
+	// migrated to before decoding. Dashboard is not migrated if it's zero.
+	SchemaVersion int
+	Migrate       *grafana.MigrateOptions
+}
+
 // Get fetches a dashboard by given slug.
 //
 // Grafana API docs: http://docs.grafana.org/http_api/dashboard/#get-dashboard
-func (ds *DashboardsService) Get(ctx context.Context, slug string) (*grafana.Dashboard, error) {
+func (ds *DashboardsService) Get(ctx context.Context, slug string, opt *DashboardGetOptions) (*grafana.Dashboard, error) {
 	u := fmt.Sprintf("/api/dashboards/db/%s", slug)
-	req, err := ds.client.NewRequest(ctx, "GET", u, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var dResp dashboardGetResponse
-	if resp, err := ds.client.Do(req, &dResp); err != nil {
-		if resp != nil {
-			if resp.StatusCode == http.StatusNotFound {
-				return nil, ErrDashboardNotFound
-			}
-		}
-		return nil, err
-	}
-
-	d := dResp.Dashboard
-	d.Meta = dResp.Meta
-	return &d, nil
+	return ds.get(ctx, u, opt)
 }
 
-// Get fetches a dashboard by given uid.
+// GetByUID fetches a dashboard by given uid.
 //
 // Grafana API docs: http://docs.grafana.org/http_api/dashboard/#get-dashboard
-func (ds *DashboardsService) GetByUID(ctx context.Context, uid string) (*grafana.Dashboard, error) {
+func (ds *DashboardsService) GetByUID(ctx context.Context, uid string, opt *DashboardGetOptions) (*grafana.Dashboard, error) {
 	u := fmt.Sprintf("/api/dashboards/uid/%s", uid)
+	return ds.get(ctx, u, opt)
+}
+
+func (ds *DashboardsService) get(ctx context.Context, u string, opt *DashboardGetOptions) (*grafana.Dashboard, error) {
 	req, err := ds.client.NewRequest(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -82,75 +84,351 @@ func (ds *DashboardsService) GetByUID(ctx context.Context, uid string) (*grafana
 		return nil, err
 	}
 
-	d := dResp.Dashboard
+	var d grafana.Dashboard
+	if data := []byte(dResp.Dashboard); len(data) > 0 {
+		if opt != nil && opt.SchemaVersion > 0 {
+			if data, err = grafana.Migrate(data, opt.SchemaVersion, opt.Migrate); err != nil {
+				return nil, err
+			}
+		}
+		if err := json.Unmarshal(data, &d); err != nil {
+			return nil, err
+		}
+		// id is left out of Dashboard's JSON so that Save matches dashboards by uid
+		var ident struct {
+			ID grafana.DashboardID `json:"id"`
+		}
+		if err := json.Unmarshal(data, &ident); err != nil {
+			return nil, err
+		}
+		d.ID = ident.ID
+	}
 	d.Meta = dResp.Meta
 	return &d, nil
 }
 
 type dashboardGetResponse struct {
-	Dashboard grafana.Dashboard      `json:"dashboard"`
+	Dashboard json.RawMessage        `json:"dashboard"`
 	Meta      *grafana.DashboardMeta `json:"meta"`
 }
 
-// Save creates a new dashboard or updates existing one.
+// DashboardSaveOptions specifies the optional parameters to the
+// DashboardsService.Save method.
+type DashboardSaveOptions struct {
+	// Message is a commit message shown in dashboard's version history.
+	Message string
+	// FolderUID is a uid of folder to save dashboard to. Dashboard is saved
+	// to General folder if it's empty.
+	FolderUID string
+	// Overwrite overwrites existing dashboard with the same title or uid
+	// regardless of its version.
+	Overwrite bool
+	// Validate validates dashboard before saving. Errors of validation are
+	// returned as validate.Errors and dashboard is not sent to Grafana then.
+	Validate bool
+	// Refetch fetches just saved dashboard by its uid to get it the same as
+	// Grafana stores it. Otherwise only ID, UID and Version of the dashboard
+	// are updated.
+	Refetch bool
+}
+
+// Save creates a new dashboard or updates existing one. Panels without ID get
+// unique ones before saving (see grafana.Dashboard.AssignPanelIDs).
+//
+// Unless opt.Overwrite is set, the dashboard's version must be the same as the
+// saved one, otherwise *DashboardConflictError is returned.
 //
 // Grafana API docs: http://docs.grafana.org/http_api/dashboard/#create-update-dashboard
-func (ds *DashboardsService) Save(ctx context.Context, dashboard *grafana.Dashboard, overwrite bool) error {
+func (ds *DashboardsService) Save(ctx context.Context, dashboard *grafana.Dashboard, opt *DashboardSaveOptions) (*SaveResult, error) {
 	u := "/api/dashboards/db"
+	if opt == nil {
+		opt = &DashboardSaveOptions{}
+	}
+
+	dashboard.AssignPanelIDs()
+	if opt.Validate {
+		if err := dashboard.Validate(); err != nil {
+			return nil, err
+		}
+	}
 
-	dReq := dashboardCreateRequest{dashboard, overwrite}
+	dReq := dashboardCreateRequest{
+		Dashboard: dashboard,
+		FolderUID: opt.FolderUID,
+		Message:   opt.Message,
+		Overwrite: opt.Overwrite,
+	}
 	req, err := ds.client.NewRequest(ctx, "POST", u, dReq)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var respBody struct {
-		Slug    string `json:"slug"`
-		Status  string `json:"status"`
-		Version int    `json:"version"`
-	}
-	if _, err := ds.client.Do(req, &respBody); err != nil {
+	var result SaveResult
+	if resp, err := ds.client.Do(req, &result); err != nil {
 		// TODO: handle errors properly
 		// 400 {"message":"Dashboard title cannot be empty", "error": ...}
 		// 404 {"status": "not-found", "message": err.Error()}
-		// 412 {"status": "name-exists", "message": err.Error()}
-		// 412 {"status": "version-mismatch", "message": err.Error()}
-		// 412 {"status": "plugin-dashboard", "message": message}
 		// 500 {"message": "failed to get quota", "error": ...}
-
-		return err
+		if resp != nil && resp.StatusCode == http.StatusPreconditionFailed {
+			return nil, newDashboardConflictError(err)
+		}
+		return nil, err
 	}
 
-	// To make our dashboard in sync with Grafana's one
-	// we need to refetch just saved dashboard by using `Get dashboard` API.
-	if respBody.Status == "success" {
-		d, err := ds.Get(ctx, respBody.Slug)
+	dashboard.ID = result.ID
+	dashboard.UID = result.UID
+	dashboard.Version = result.Version
+
+	if opt.Refetch {
+		d, err := ds.GetByUID(ctx, result.UID, nil)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		*dashboard = *d
 	}
 
-	return nil
+	return &result, nil
+}
+
+// SaveResult is a result of saving of a dashboard.
+type SaveResult struct {
+	ID      grafana.DashboardID `json:"id"`
+	UID     string              `json:"uid"`
+	URL     string              `json:"url"`
+	Slug    string              `json:"slug"`
+	Version uint64              `json:"version"`
+	Status  string              `json:"status"`
 }
 
 type dashboardCreateRequest struct {
 	Dashboard *grafana.Dashboard `json:"dashboard"`
+	FolderUID string             `json:"folderUid,omitempty"`
+	Message   string             `json:"message,omitempty"`
 	Overwrite bool               `json:"overwrite"`
 }
 
+// Statuses of DashboardConflictError.
+const (
+	// VersionMismatchStatus means that the dashboard was changed by someone
+	// else since it was fetched.
+	VersionMismatchStatus = "version-mismatch"
+	// NameExistsStatus means that another dashboard with the same title
+	// exists in the folder.
+	NameExistsStatus = "name-exists"
+	// PluginDashboardStatus means that the dashboard belongs to a plugin.
+	PluginDashboardStatus = "plugin-dashboard"
+)
+
+// DashboardConflictError is returned by DashboardsService.Save when Grafana
+// refuses to save a dashboard because it conflicts with existing one. Conflicts
+// could be resolved by refetching the dashboard or saving it with Overwrite.
+type DashboardConflictError struct {
+	Status  string
+	Message string
+
+	Response *http.Response
+}
+
+func (e *DashboardConflictError) Error() string {
+	return fmt.Sprintf("dashboard conflict (%s): %s", e.Status, e.Message)
+}
+
+// newDashboardConflictError makes DashboardConflictError of Grafana's response
+// with 412 status.
+func newDashboardConflictError(err error) error {
+	errResp, ok := err.(*ErrorResponse)
+	if !ok {
+		return err
+	}
+
+	var body struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(errResp.Message), &body); err != nil {
+		return errResp
+	}
+
+	return &DashboardConflictError{
+		Status:   body.Status,
+		Message:  body.Message,
+		Response: errResp.Response,
+	}
+}
+
+// Versions fetches versions of a dashboard with given id, the newest first.
+// Up to limit versions are fetched starting from start-th one.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_versions/#get-all-dashboard-versions
+func (ds *DashboardsService) Versions(ctx context.Context, dashboardID grafana.DashboardID, limit, start int) ([]*grafana.DashboardVersion, error) {
+	u := fmt.Sprintf("/api/dashboards/id/%d/versions", dashboardID)
+
+	opt := struct {
+		Limit int `url:"limit,omitempty"`
+		Start int `url:"start,omitempty"`
+	}{limit, start}
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ds.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*grafana.DashboardVersion
+	if resp, err := ds.client.Do(req, &versions); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrDashboardNotFound
+		}
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// VersionsAll returns iterator over all versions of a dashboard with given id,
+// the newest first. Versions are fetched by pages of given size.
+func (ds *DashboardsService) VersionsAll(dashboardID grafana.DashboardID, pageSize int) *Iterator[*grafana.DashboardVersion] {
+	return newOffsetIterator(0, pageSize, func(ctx context.Context, start int) ([]*grafana.DashboardVersion, error) {
+		return ds.Versions(ctx, dashboardID, pageSize, start)
+	})
+}
+
+// Version fetches a version of a dashboard with given id. The version contains
+// the dashboard as it was saved.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_versions/#get-dashboard-version
+func (ds *DashboardsService) Version(ctx context.Context, dashboardID grafana.DashboardID, versionID uint64) (*grafana.DashboardVersion, error) {
+	u := fmt.Sprintf("/api/dashboards/id/%d/versions/%d", dashboardID, versionID)
+	req, err := ds.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var version grafana.DashboardVersion
+	if resp, err := ds.client.Do(req, &version); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrDashboardVersionNotFound
+		}
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// Restore restores a dashboard with given uid to given version. Restoring
+// creates a new version of the dashboard.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_versions/#restore-dashboard
+func (ds *DashboardsService) Restore(ctx context.Context, dashboardUID string, version uint64) (*SaveResult, error) {
+	u := fmt.Sprintf("/api/dashboards/uid/%s/restore", dashboardUID)
+	body := struct {
+		Version uint64 `json:"version"`
+	}{version}
+	req, err := ds.client.NewRequest(ctx, "POST", u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SaveResult
+	if resp, err := ds.client.Do(req, &result); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrDashboardVersionNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DashboardVersionRef refers to a version of a dashboard.
+type DashboardVersionRef struct {
+	DashboardID grafana.DashboardID `json:"dashboardId"`
+	Version     uint64              `json:"version"`
+}
+
+type dashboardDiffType string
+
+// Types of diff calculated by DashboardsService.CalculateDiff.
+const (
+	JSONDashboardDiff  dashboardDiffType = "json"
+	BasicDashboardDiff dashboardDiffType = "basic"
+)
+
+// CalculateDiff calculates difference between two versions of dashboards. The
+// diff is rendered by Grafana as HTML.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_versions/#compare-dashboard-versions
+func (ds *DashboardsService) CalculateDiff(ctx context.Context, base, new DashboardVersionRef, diffType dashboardDiffType) (string, error) {
+	u := "/api/dashboards/calculate-diff"
+	body := struct {
+		Base     DashboardVersionRef `json:"base"`
+		New      DashboardVersionRef `json:"new"`
+		DiffType dashboardDiffType   `json:"diffType"`
+	}{base, new, diffType}
+	req, err := ds.client.NewRequest(ctx, "POST", u, body)
+	if err != nil {
+		return "", err
+	}
+
+	var diff bytes.Buffer
+	if resp, err := ds.client.Do(req, &diff); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", ErrDashboardVersionNotFound
+		}
+		return "", err
+	}
+
+	return diff.String(), nil
+}
+
+type searchType string
+
+// Types of entities found by DashboardsService.Search.
+const (
+	DashboardSearchType searchType = "dash-db"
+	FolderSearchType    searchType = "dash-folder"
+)
+
+type searchSort string
+
+// Sort orders of DashboardsService.Search results.
+const (
+	AlphaAscSearchSort  searchSort = "alpha-asc"
+	AlphaDescSearchSort searchSort = "alpha-desc"
+)
+
+type searchPermission string
+
+// Permissions that found by DashboardsService.Search entities should be
+// granted to the user.
+const (
+	ViewSearchPermission searchPermission = "View"
+	EditSearchPermission searchPermission = "Edit"
+)
+
 // DashboardSearchOptions specifies the optional parameters to the
 // DashboardsService.Search method.
 type DashboardSearchOptions struct {
-	Query     string   `url:"query,omitempty"`
-	Tags      []string `url:"tags,omitempty"`
-	IsStarred bool     `url:"starred,omitempty"`
-	Limit     int      `url:"limit,omitempty"`
+	Query         string           `url:"query,omitempty"`
+	Tags          []string         `url:"tag,omitempty"`
+	Type          searchType       `url:"type,omitempty"`
+	DashboardIDs  []int64          `url:"dashboardIds,omitempty"`
+	DashboardUIDs []string         `url:"dashboardUIDs,omitempty"`
+	FolderIDs     []int64          `url:"folderIds,omitempty"`
+	FolderUIDs    []string         `url:"folderUIDs,omitempty"`
+	IsStarred     bool             `url:"starred,omitempty"`
+	Permission    searchPermission `url:"permission,omitempty"`
+	Sort          searchSort       `url:"sort,omitempty"`
+	Limit         int              `url:"limit,omitempty"` // Grafana uses 1000 if it's not set
+	Page          int              `url:"page,omitempty"`  // starts from 1
 }
 
-// Search searches dashboards with given criteria
+// Search searches dashboards and folders with given criteria
 //
-//  Grafana API docs: http://docs.grafana.org/http_api/dashboard/#search-dashboards
+// Grafana API docs: http://docs.grafana.org/http_api/folder_dashboard_search/
 func (ds *DashboardsService) Search(ctx context.Context, opt *DashboardSearchOptions) ([]*DashboardHit, error) {
 	u := "/api/search"
 
@@ -173,13 +451,104 @@ func (ds *DashboardsService) Search(ctx context.Context, opt *DashboardSearchOpt
 	return hits, nil
 }
 
-// DashboardHit represents a found by DashboardsService.Search dashboard
+// defaultSearchLimit is a limit of search results that Grafana uses if the
+// limit isn't given.
+const defaultSearchLimit = 1000
+
+// SearchAll returns iterator over all dashboards and folders found with given
+// criteria. Results are fetched page by page starting from opt.Page.
+func (ds *DashboardsService) SearchAll(opt *DashboardSearchOptions) *Iterator[*DashboardHit] {
+	pageOpt := DashboardSearchOptions{}
+	if opt != nil {
+		pageOpt = *opt
+	}
+	if pageOpt.Limit <= 0 {
+		pageOpt.Limit = defaultSearchLimit
+	}
+
+	return newPageIterator(pageOpt.Page, pageOpt.Limit, func(ctx context.Context, page int) ([]*DashboardHit, error) {
+		pageOpt.Page = page
+		return ds.Search(ctx, &pageOpt)
+	})
+}
+
+// DashboardHit represents a found by DashboardsService.Search dashboard or folder
 type DashboardHit struct {
-	ID        int64    `json:"id"`
-	UID       string   `json:"uid"`
-	Title     string   `json:"title"`
-	URI       string   `json:"uri"`
-	URL       string   `json:"uri"`
-	Tags      []string `json:"tags"`
-	IsStarred bool     `json:"isStarred"`
+	ID          int64      `json:"id"`
+	UID         string     `json:"uid"`
+	Title       string     `json:"title"`
+	URI         string     `json:"uri"` // deprecated, ie. db/slug
+	URL         string     `json:"url"`
+	Slug        string     `json:"slug"`
+	Type        searchType `json:"type"`
+	Tags        []string   `json:"tags"`
+	IsStarred   bool       `json:"isStarred"`
+	FolderID    int64      `json:"folderId"`
+	FolderUID   string     `json:"folderUid"`
+	FolderTitle string     `json:"folderTitle"`
+	FolderURL   string     `json:"folderUrl"`
+}
+
+// GetPermissions fetches access control list of a dashboard with given id,
+// including permissions inherited from its folder.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_permissions/#get-permissions-for-a-dashboard
+func (ds *DashboardsService) GetPermissions(ctx context.Context, id grafana.DashboardID) ([]*grafana.Permission, error) {
+	return getPermissions(ctx, ds.client, dashboardPermissionsURL(id), ErrDashboardNotFound)
+}
+
+// GetPermissionsByUID fetches access control list of a dashboard with given
+// uid, including permissions inherited from its folder.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_permissions/#get-permissions-for-a-dashboard
+func (ds *DashboardsService) GetPermissionsByUID(ctx context.Context, uid string) ([]*grafana.Permission, error) {
+	return getPermissions(ctx, ds.client, dashboardPermissionsURLByUID(uid), ErrDashboardNotFound)
+}
+
+// UpdatePermissions replaces access control list of a dashboard with given id
+// with perms. Inherited permissions are left intact.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_permissions/#update-permissions-for-a-dashboard
+func (ds *DashboardsService) UpdatePermissions(ctx context.Context, id grafana.DashboardID, perms []*grafana.Permission) error {
+	return updatePermissions(ctx, ds.client, dashboardPermissionsURL(id), perms, ErrDashboardNotFound)
+}
+
+// UpdatePermissionsByUID replaces access control list of a dashboard with given
+// uid with perms. Inherited permissions are left intact.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/dashboard_permissions/#update-permissions-for-a-dashboard
+func (ds *DashboardsService) UpdatePermissionsByUID(ctx context.Context, uid string, perms []*grafana.Permission) error {
+	return updatePermissions(ctx, ds.client, dashboardPermissionsURLByUID(uid), perms, ErrDashboardNotFound)
+}
+
+// AddPermission adds p to access control list of a dashboard with given id,
+// replacing permission of the same subject if any.
+func (ds *DashboardsService) AddPermission(ctx context.Context, id grafana.DashboardID, p *grafana.Permission) error {
+	return addPermission(ctx, ds.client, dashboardPermissionsURL(id), p, ErrDashboardNotFound)
+}
+
+// AddPermissionByUID adds p to access control list of a dashboard with given
+// uid, replacing permission of the same subject if any.
+func (ds *DashboardsService) AddPermissionByUID(ctx context.Context, uid string, p *grafana.Permission) error {
+	return addPermission(ctx, ds.client, dashboardPermissionsURLByUID(uid), p, ErrDashboardNotFound)
+}
+
+// RemovePermission removes permission of the subject of p from access control
+// list of a dashboard with given id.
+func (ds *DashboardsService) RemovePermission(ctx context.Context, id grafana.DashboardID, p *grafana.Permission) error {
+	return removePermission(ctx, ds.client, dashboardPermissionsURL(id), p, ErrDashboardNotFound)
+}
+
+// RemovePermissionByUID removes permission of the subject of p from access
+// control list of a dashboard with given uid.
+func (ds *DashboardsService) RemovePermissionByUID(ctx context.Context, uid string, p *grafana.Permission) error {
+	return removePermission(ctx, ds.client, dashboardPermissionsURLByUID(uid), p, ErrDashboardNotFound)
+}
+
+func dashboardPermissionsURL(id grafana.DashboardID) string {
+	return fmt.Sprintf("/api/dashboards/id/%d/permissions", id)
+}
+
+func dashboardPermissionsURLByUID(uid string) string {
+	return fmt.Sprintf("/api/dashboards/uid/%s/permissions", url.PathEscape(uid))
 }
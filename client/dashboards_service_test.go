@@ -15,14 +15,19 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/utilitywarehouse/go-grafana/grafana"
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 func TestDashboardsService_Get(t *testing.T) {
@@ -38,7 +43,7 @@ func TestDashboardsService_Get(t *testing.T) {
 		fmt.Fprint(w, `{"dashboard": {"id": 1, "title": "`+title+`"}}`)
 	})
 
-	d, err := client.Dashboards.Get(context.Background(), slug)
+	d, err := client.Dashboards.Get(context.Background(), slug, nil)
 	if err != nil {
 		t.Fatalf("Dashboards.Get returned error: %v", err)
 	}
@@ -54,41 +59,166 @@ func TestDashboardsService_Get(t *testing.T) {
 
 }
 
+func TestDashboardsService_GetByUID_Migrate(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	uid := "uid"
+	mux.HandleFunc("/api/dashboards/uid/"+uid, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"dashboard": {"schemaVersion": 14, "rows": [{"panels": [{"type": "text", "span": 12}]}]}}`)
+	})
+
+	opt := &DashboardGetOptions{SchemaVersion: grafana.GridLayoutSchemaVersion}
+	d, err := client.Dashboards.GetByUID(context.Background(), uid, opt)
+	if err != nil {
+		t.Fatalf("Dashboards.GetByUID returned error: %v", err)
+	}
+
+	if d.SchemaVersion != grafana.GridLayoutSchemaVersion {
+		t.Errorf("Dashboards.GetByUID returned SchemaVersion %d, want %d", d.SchemaVersion, grafana.GridLayoutSchemaVersion)
+	}
+	if len(d.Rows) != 0 || len(d.Panels) != 1 {
+		t.Fatalf("Dashboards.GetByUID returned %d rows and %d panels, want 0 and 1", len(d.Rows), len(d.Panels))
+	}
+	want := &panel.GridPos{X: 0, Y: 0, W: 24, H: 7}
+	if got := d.Panels[0].GeneralOptions().GridPos; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dashboards.GetByUID returned panel's GridPos %+v, want %+v", got, want)
+	}
+}
+
 func TestDashboardsService_Save_New(t *testing.T) {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
 	baseURL, _ := url.Parse(server.URL + "/")
 	client := NewClient(baseURL, "", nil)
 
-	slug := "slug"
 	mux.HandleFunc("/api/dashboards/db", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
-		fmt.Fprint(w, `{"slug": "`+slug+`", "version": 1, "status": "success"}`)
+		fmt.Fprint(w, `{"id": 1, "uid": "uid", "url": "/d/uid/slug", "slug": "slug", "version": 1, "status": "success"}`)
 	})
-	title := "title"
-	mux.HandleFunc("/api/dashboards/db/"+slug, func(w http.ResponseWriter, r *http.Request) {
-		testMethod(t, r, "GET")
-		fmt.Fprint(w, `{"dashboard": {"id": 1, "title": "`+title+`", "version": 2}}`)
+	mux.HandleFunc("/api/dashboards/uid/uid", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Dashboards.Save shouldn't refetch dashboard")
 	})
 
+	title := "title"
 	d := grafana.NewDashboard(title)
-	overwrite := false
-	err := client.Dashboards.Save(context.Background(), d, overwrite)
+	result, err := client.Dashboards.Save(context.Background(), d, &DashboardSaveOptions{Overwrite: false})
 	if err != nil {
 		t.Fatalf("Dashboards.Save returned error: %v", err)
 	}
 
+	wantResult := &SaveResult{ID: 1, UID: "uid", URL: "/d/uid/slug", Slug: "slug", Version: 1, Status: "success"}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("Dashboards.Save\nreturned: %+v\nwant: %+v", result, wantResult)
+	}
+
 	want := grafana.NewDashboard(title)
 	want.ID = grafana.DashboardID(1)
-	want.Version = 2
-	if !reflect.DeepEqual(d.ID, want.ID) {
-		t.Errorf("Dashboards.Save\nreturned: %+v\nwant: %+v", d.ID, want.ID)
+	want.UID = "uid"
+	want.Version = 1
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("Dashboards.Save\nreturned: %+v\nwant: %+v", d, want)
 	}
+}
+
+func TestDashboardsService_Save_Refetch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/db", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id": 1, "uid": "uid", "url": "/d/uid/slug", "slug": "slug", "version": 1, "status": "success"}`)
+	})
+	title := "title"
+	mux.HandleFunc("/api/dashboards/uid/uid", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"dashboard": {"id": 1, "uid": "uid", "title": "`+title+`", "version": 2}, "meta": {"slug": "slug"}}`)
+	})
 
-	if !reflect.DeepEqual(d.Version, want.Version) {
-		t.Errorf("Dashboards.Save\nreturned Version: %+v\nwant: %+v", d.Version, want.Version)
+	d := grafana.NewDashboard(title)
+	if _, err := client.Dashboards.Save(context.Background(), d, &DashboardSaveOptions{Refetch: true}); err != nil {
+		t.Fatalf("Dashboards.Save returned error: %v", err)
 	}
 
+	if d.ID != 1 || d.Version != 2 || d.Meta == nil || d.Meta.Slug != "slug" {
+		t.Errorf("Dashboards.Save\nreturned: %+v", d)
+	}
+}
+
+func TestDashboardsService_Save_Options(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/db", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body struct {
+			Dashboard map[string]interface{} `json:"dashboard"`
+			FolderUID string                 `json:"folderUid"`
+			Message   string                 `json:"message"`
+			Overwrite bool                   `json:"overwrite"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Decoding request body returned error %s", err)
+		}
+		if _, ok := body.Dashboard["id"]; ok {
+			t.Errorf("Dashboards.Save sent dashboard's id, it's matched by uid")
+		}
+		if body.Dashboard["version"] != 2.0 || body.FolderUID != "folder" || body.Message != "Update" || body.Overwrite {
+			t.Errorf("Dashboards.Save sent %+v", body)
+		}
+
+		w.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprint(w, `{"status": "version-mismatch", "message": "The dashboard has been changed by someone else"}`)
+	})
+
+	d := grafana.NewDashboard("title")
+	d.ID = 1
+	d.Version = 2
+	opt := &DashboardSaveOptions{Message: "Update", FolderUID: "folder"}
+	_, err := client.Dashboards.Save(context.Background(), d, opt)
+
+	conflict, ok := err.(*DashboardConflictError)
+	if !ok {
+		t.Fatalf("Dashboards.Save: expected *DashboardConflictError, got %v", err)
+	}
+	if conflict.Status != VersionMismatchStatus || conflict.Message != "The dashboard has been changed by someone else" {
+		t.Errorf("Dashboards.Save returned %+v", conflict)
+	}
+}
+
+func TestDashboardsService_Save_Invalid(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/db", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Dashboards.Save: invalid dashboard shouldn't be sent")
+	})
+
+	d := grafana.NewDashboard("")
+	d.Refresh = "5 minutes"
+	_, err := client.Dashboards.Save(context.Background(), d, &DashboardSaveOptions{Validate: true})
+
+	errs, ok := err.(validate.Errors)
+	if !ok {
+		t.Fatalf("Dashboards.Save: expected validate.Errors, got %v", err)
+	}
+	var paths []string
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	want := []string{"/title", "/refresh"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Dashboards.Save\nreturned errors of: %v\nwant: %v", paths, want)
+	}
 }
 
 func TestDashboardsService_Search(t *testing.T) {
@@ -98,30 +228,303 @@ func TestDashboardsService_Search(t *testing.T) {
 	client := NewClient(baseURL, "", nil)
 
 	mux.HandleFunc("/api/search", func(w http.ResponseWriter, r *http.Request) {
-		// TODO: check querystring params here
 		testMethod(t, r, "GET")
-		fmt.Fprint(w, `[{"id": 1}, {"id": 2}]`)
+		testFormValues(t, r, url.Values{
+			"query":         {"q"},
+			"tag":           {"tag1", "tag2"},
+			"type":          {"dash-db"},
+			"dashboardUIDs": {"uid1"},
+			"folderIds":     {"1", "2"},
+			"folderUIDs":    {"folder"},
+			"starred":       {"true"},
+			"permission":    {"Edit"},
+			"sort":          {"alpha-desc"},
+			"limit":         {"10"},
+			"page":          {"2"},
+		})
+		fmt.Fprint(w, `[{
+			"id": 1,
+			"uid": "uid1",
+			"title": "Dashboard",
+			"uri": "db/dashboard",
+			"url": "/d/uid1/dashboard",
+			"slug": "dashboard",
+			"type": "dash-db",
+			"tags": ["tag1", "tag2"],
+			"isStarred": true,
+			"folderId": 1,
+			"folderUid": "folder",
+			"folderTitle": "Folder",
+			"folderUrl": "/dashboards/f/folder/folder"
+		}, {"id": 2}]`)
 	})
 
 	opt := &DashboardSearchOptions{
-		Query:     "q",
-		Tags:      []string{"tag1", "tag2"},
-		IsStarred: true,
-		Limit:     10,
+		Query:         "q",
+		Tags:          []string{"tag1", "tag2"},
+		Type:          DashboardSearchType,
+		DashboardUIDs: []string{"uid1"},
+		FolderIDs:     []int64{1, 2},
+		FolderUIDs:    []string{"folder"},
+		IsStarred:     true,
+		Permission:    EditSearchPermission,
+		Sort:          AlphaDescSearchSort,
+		Limit:         10,
+		Page:          2,
 	}
 	dashboards, err := client.Dashboards.Search(context.Background(), opt)
 	if err != nil {
 		t.Fatalf("Dashboards.Search returned error: %v", err)
 	}
 
-	want := []*DashboardHit{{ID: int64(1)}, {ID: int64(2)}}
+	want := []*DashboardHit{{
+		ID:          1,
+		UID:         "uid1",
+		Title:       "Dashboard",
+		URI:         "db/dashboard",
+		URL:         "/d/uid1/dashboard",
+		Slug:        "dashboard",
+		Type:        DashboardSearchType,
+		Tags:        []string{"tag1", "tag2"},
+		IsStarred:   true,
+		FolderID:    1,
+		FolderUID:   "folder",
+		FolderTitle: "Folder",
+		FolderURL:   "/dashboards/f/folder/folder",
+	}, {ID: 2}}
 	if !reflect.DeepEqual(dashboards, want) {
 		t.Errorf("Dashboards.Search returned %+v, want %+v", dashboards, want)
 	}
 }
 
+func TestDashboardsService_SearchAll(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	var requests int
+	mux.HandleFunc("/api/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+		switch page := r.URL.Query().Get("page"); page {
+		case "1":
+			fmt.Fprint(w, `[{"id": 1}, {"id": 2}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id": 3}]`)
+		default:
+			t.Errorf("Dashboards.SearchAll requested unexpected page %s", page)
+		}
+	})
+
+	it := client.Dashboards.SearchAll(&DashboardSearchOptions{Limit: 2})
+	var ids []int64
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Dashboards.SearchAll returned error: %v", err)
+	}
+	if want := []int64{1, 2, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Dashboards.SearchAll returned %v, want %v", ids, want)
+	}
+	if requests != 2 {
+		t.Errorf("Dashboards.SearchAll made %d requests, want 2", requests)
+	}
+}
+
 func testMethod(t *testing.T, r *http.Request, want string) {
 	if got := r.Method; got != want {
 		t.Errorf("Request method: %v, want %v", got, want)
 	}
 }
+
+func testFormValues(t *testing.T, r *http.Request, want url.Values) {
+	if err := r.ParseForm(); err != nil {
+		t.Fatalf("Request.ParseForm returned error %s", err)
+	}
+	if got := r.Form; !reflect.DeepEqual(got, want) {
+		t.Errorf("Request parameters: %v, want %v", got, want)
+	}
+}
+
+func testBody(t *testing.T, r *http.Request, want string) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("Reading request body returned error %s", err)
+	}
+	if got := string(data); got != want {
+		t.Errorf("Request body: %s, want %s", got, want)
+	}
+}
+
+func TestDashboardsService_Versions(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/id/1/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, url.Values{"limit": {"2"}, "start": {"1"}})
+		fmt.Fprint(w, `[
+			{"id": 3, "dashboardId": 1, "parentVersion": 2, "restoredFrom": 0, "version": 3, "created": "2017-06-08T17:24:33-04:00", "createdBy": "admin", "message": "Updated panels"},
+			{"id": 2, "dashboardId": 1, "parentVersion": 1, "restoredFrom": 0, "version": 2, "created": "2017-06-08T17:23:33-04:00", "createdBy": "admin", "message": ""}
+		]`)
+	})
+
+	versions, err := client.Dashboards.Versions(context.Background(), 1, 2, 1)
+	if err != nil {
+		t.Fatalf("Dashboards.Versions returned error: %v", err)
+	}
+
+	created, _ := time.Parse(time.RFC3339, "2017-06-08T17:24:33-04:00")
+	want := &grafana.DashboardVersion{
+		ID:            3,
+		DashboardID:   1,
+		ParentVersion: 2,
+		Version:       3,
+		Created:       created,
+		CreatedBy:     "admin",
+		Message:       "Updated panels",
+	}
+	if len(versions) != 2 {
+		t.Fatalf("Dashboards.Versions returned %d versions, want 2", len(versions))
+	}
+	if !reflect.DeepEqual(versions[0], want) {
+		t.Errorf("Dashboards.Versions\nreturned: %+v\nwant: %+v", versions[0], want)
+	}
+}
+
+func TestDashboardsService_Version(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/id/1/versions/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": 2, "dashboardId": 1, "parentVersion": 1, "version": 2, "createdBy": "admin", "data": {"title": "Title"}}`)
+	})
+	mux.HandleFunc("/api/dashboards/id/1/versions/3", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Dashboard version not found"}`, http.StatusNotFound)
+	})
+
+	version, err := client.Dashboards.Version(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("Dashboards.Version returned error: %v", err)
+	}
+	if version.Version != 2 || version.Dashboard == nil || version.Dashboard.Title != "Title" {
+		t.Errorf("Dashboards.Version returned %+v", version)
+	}
+
+	if _, err := client.Dashboards.Version(context.Background(), 1, 3); err != ErrDashboardVersionNotFound {
+		t.Errorf("Dashboards.Version returned error %v, want %v", err, ErrDashboardVersionNotFound)
+	}
+}
+
+func TestDashboardsService_Restore(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/uid/uid/restore", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"version":2}`+"\n")
+		fmt.Fprint(w, `{"id": 1, "slug": "title", "status": "success", "uid": "uid", "version": 4}`)
+	})
+
+	result, err := client.Dashboards.Restore(context.Background(), "uid", 2)
+	if err != nil {
+		t.Fatalf("Dashboards.Restore returned error: %v", err)
+	}
+	if want := (&SaveResult{ID: 1, UID: "uid", Slug: "title", Version: 4, Status: "success"}); !reflect.DeepEqual(result, want) {
+		t.Errorf("Dashboards.Restore\nreturned: %+v\nwant: %+v", result, want)
+	}
+}
+
+func TestDashboardsService_CalculateDiff(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/calculate-diff", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"base":{"dashboardId":1,"version":1},"new":{"dashboardId":1,"version":2},"diffType":"basic"}`+"\n")
+		fmt.Fprint(w, `<div class="diff-group">Title changed</div>`)
+	})
+
+	base := DashboardVersionRef{DashboardID: 1, Version: 1}
+	new := DashboardVersionRef{DashboardID: 1, Version: 2}
+	diff, err := client.Dashboards.CalculateDiff(context.Background(), base, new, BasicDashboardDiff)
+	if err != nil {
+		t.Fatalf("Dashboards.CalculateDiff returned error: %v", err)
+	}
+	if want := `<div class="diff-group">Title changed</div>`; diff != want {
+		t.Errorf("Dashboards.CalculateDiff returned %q, want %q", diff, want)
+	}
+}
+
+func TestDashboardsService_GetPermissions(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/id/1/permissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"dashboardId": 1, "role": "Viewer", "permission": 1, "permissionName": "View", "inherited": true},
+			{"dashboardId": 1, "teamId": 2, "team": "Platform", "permission": 2, "permissionName": "Edit"},
+			{"dashboardId": 1, "userId": 3, "userLogin": "user", "userEmail": "user@localhost", "permission": 4, "permissionName": "Admin"}
+		]`)
+	})
+
+	perms, err := client.Dashboards.GetPermissions(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Dashboards.GetPermissions returned error: %v", err)
+	}
+
+	want := []*grafana.Permission{
+		{Role: grafana.ViewerOrgRole, Permission: grafana.ViewPermission, PermissionName: "View", Inherited: true},
+		{TeamID: 2, Team: "Platform", Permission: grafana.EditPermission, PermissionName: "Edit"},
+		{UserID: 3, UserLogin: "user", UserEmail: "user@localhost", Permission: grafana.AdminPermission, PermissionName: "Admin"},
+	}
+	if !reflect.DeepEqual(perms, want) {
+		t.Errorf("Dashboards.GetPermissions returned %+v, want %+v", perms, want)
+	}
+
+	if _, err := client.Dashboards.GetPermissions(context.Background(), 2); err != ErrDashboardNotFound {
+		t.Errorf("Dashboards.GetPermissions returned error %v, want %v", err, ErrDashboardNotFound)
+	}
+}
+
+func TestDashboardsService_AddPermissionByUID(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/dashboards/uid/uid/permissions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[
+				{"role": "Viewer", "permission": 1, "inherited": true},
+				{"teamId": 2, "team": "Platform", "permission": 1}
+			]`)
+		case "POST":
+			// Permission of the team is replaced and inherited one isn't sent
+			testBody(t, r, `{"items":[{"teamId":2,"permission":2}]}`+"\n")
+			fmt.Fprint(w, `{"message": "Dashboard permissions updated"}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+
+	p := grafana.NewTeamPermission(2, grafana.EditPermission)
+	if err := client.Dashboards.AddPermissionByUID(context.Background(), "uid", p); err != nil {
+		t.Errorf("Dashboards.AddPermissionByUID returned error: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// FoldersService communicates with folder methods of the Grafana API.
+type FoldersService struct {
+	client *Client
+}
+
+// NewFoldersService returns a new FoldersService.
+func NewFoldersService(client *Client) *FoldersService {
+	return &FoldersService{
+		client: client,
+	}
+}
+
+// ErrFolderNotFound represents an error if folder not found.
+var ErrFolderNotFound = errors.New("Folder not found")
+
+// GetPermissions fetches access control list of a folder with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/folder_permissions/#get-permissions-for-a-folder
+func (fs *FoldersService) GetPermissions(ctx context.Context, uid string) ([]*grafana.Permission, error) {
+	return getPermissions(ctx, fs.client, folderPermissionsURL(uid), ErrFolderNotFound)
+}
+
+// UpdatePermissions replaces access control list of a folder with given uid
+// with perms. Dashboards of the folder inherit these permissions.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/folder_permissions/#update-permissions-for-a-folder
+func (fs *FoldersService) UpdatePermissions(ctx context.Context, uid string, perms []*grafana.Permission) error {
+	return updatePermissions(ctx, fs.client, folderPermissionsURL(uid), perms, ErrFolderNotFound)
+}
+
+// AddPermission adds p to access control list of a folder with given uid,
+// replacing permission of the same subject if any.
+func (fs *FoldersService) AddPermission(ctx context.Context, uid string, p *grafana.Permission) error {
+	return addPermission(ctx, fs.client, folderPermissionsURL(uid), p, ErrFolderNotFound)
+}
+
+// RemovePermission removes permission of the subject of p from access control
+// list of a folder with given uid.
+func (fs *FoldersService) RemovePermission(ctx context.Context, uid string, p *grafana.Permission) error {
+	return removePermission(ctx, fs.client, folderPermissionsURL(uid), p, ErrFolderNotFound)
+}
+
+func folderPermissionsURL(uid string) string {
+	return fmt.Sprintf("/api/folders/%s/permissions", url.PathEscape(uid))
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestFoldersService_AddPermission(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/folders/folder/permissions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"role": "Editor", "permission": 2}]`)
+		case "POST":
+			testBody(t, r, `{"items":[{"role":"Editor","permission":2},{"userId":3,"permission":4}]}`+"\n")
+			fmt.Fprint(w, `{"message": "Folder permissions updated"}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+
+	p := grafana.NewUserPermission(3, grafana.AdminPermission)
+	if err := client.Folders.AddPermission(context.Background(), "folder", p); err != nil {
+		t.Errorf("Folders.AddPermission returned error: %v", err)
+	}
+	if err := client.Folders.AddPermission(context.Background(), "unknown", p); err != ErrFolderNotFound {
+		t.Errorf("Folders.AddPermission returned error %v, want %v", err, ErrFolderNotFound)
+	}
+}
+
+func TestFoldersService_RemovePermission(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	var updates int
+	mux.HandleFunc("/api/folders/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/folders/my%20folder/permissions" {
+			t.Errorf("Unexpected escaped path %s", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"role": "Viewer", "permission": 1}, {"teamId": 2, "permission": 2}]`)
+		case "POST":
+			updates++
+			testBody(t, r, `{"items":[{"role":"Viewer","permission":1}]}`+"\n")
+			fmt.Fprint(w, `{"message": "Folder permissions updated"}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	if err := client.Folders.RemovePermission(ctx, "my folder", grafana.NewTeamPermission(2, 0)); err != nil {
+		t.Errorf("Folders.RemovePermission returned error: %v", err)
+	}
+	// The list isn't updated if there's nothing to remove
+	if err := client.Folders.RemovePermission(ctx, "my folder", grafana.NewTeamPermission(3, 0)); err != nil {
+		t.Errorf("Folders.RemovePermission returned error: %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("Folders.RemovePermission updated permissions %d times, want 1", updates)
+	}
+}
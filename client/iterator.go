@@ -0,0 +1,114 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "context"
+
+// Iterator iterates over results of list methods of the API that are fetched
+// page by page. The next page is fetched only when all results of the previous
+// one are consumed, so it's possible to stop iteration at any moment.
+//
+//	it := client.Dashboards.SearchAll(opt)
+//	for it.Next(ctx) {
+//		hit := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch func(ctx context.Context) (items []T, more bool, err error)
+
+	items []T
+	value T
+	done  bool
+	err   error
+}
+
+// newIterator creates Iterator that gets pages of results from fetch until it
+// reports that there are no more pages.
+func newIterator[T any](fetch func(ctx context.Context) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch}
+}
+
+// newPageIterator creates Iterator over results of endpoints paginated by page
+// number starting from 1. Pages are considered to be over when a page has less
+// than perPage results.
+func newPageIterator[T any](page, perPage int, fetch func(ctx context.Context, page int) ([]T, error)) *Iterator[T] {
+	if page <= 0 {
+		page = 1
+	}
+	return newIterator(func(ctx context.Context) ([]T, bool, error) {
+		items, err := fetch(ctx, page)
+		if err != nil {
+			return nil, false, err
+		}
+		page++
+		return items, len(items) > 0 && len(items) >= perPage, nil
+	})
+}
+
+// newOffsetIterator creates Iterator over results of endpoints paginated by
+// offset of the first result. Pages are considered to be over when a page has
+// less than limit results.
+func newOffsetIterator[T any](start, limit int, fetch func(ctx context.Context, start int) ([]T, error)) *Iterator[T] {
+	return newIterator(func(ctx context.Context) ([]T, bool, error) {
+		items, err := fetch(ctx, start)
+		if err != nil {
+			return nil, false, err
+		}
+		start += len(items)
+		return items, len(items) > 0 && len(items) >= limit, nil
+	})
+}
+
+// Next advances the iterator to the next result, fetching the next page if
+// needed. It returns false when there are no more results, an error occurred
+// or ctx is done.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.done {
+			return false
+		}
+
+		items, more, err := it.fetch(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = items
+		it.done = !more
+	}
+
+	it.value, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Value returns the current result.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error occurred during iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
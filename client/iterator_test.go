@@ -0,0 +1,114 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIterator_Pages(t *testing.T) {
+	pages := map[int][]int{1: {1, 2}, 2: {3, 4}, 3: {}}
+	var fetched []int
+	it := newPageIterator(0, 2, func(ctx context.Context, page int) ([]int, error) {
+		fetched = append(fetched, page)
+		return pages[page], nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iterator returned error %s", err)
+	}
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator returned %v, want %v", got, want)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("Iterator fetched pages %v, want %v", fetched, want)
+	}
+}
+
+func TestIterator_Offsets(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	var fetched []int
+	it := newOffsetIterator(0, 2, func(ctx context.Context, start int) ([]int, error) {
+		fetched = append(fetched, start)
+		end := start + 2
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[start:end], nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if !reflect.DeepEqual(got, items) {
+		t.Errorf("Iterator returned %v, want %v", got, items)
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("Iterator fetched offsets %v, want %v", fetched, want)
+	}
+}
+
+func TestIterator_EarlyTermination(t *testing.T) {
+	var requests int
+	it := newPageIterator(1, 2, func(ctx context.Context, page int) ([]int, error) {
+		requests++
+		return []int{page*2 - 1, page * 2}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for it.Next(ctx) {
+		if it.Value() == 3 {
+			cancel()
+		}
+	}
+	if err := it.Err(); err != context.Canceled {
+		t.Errorf("Iterator returned error %v, want %v", err, context.Canceled)
+	}
+	if requests != 2 {
+		t.Errorf("Iterator made %d requests, want 2", requests)
+	}
+}
+
+func TestIterator_Error(t *testing.T) {
+	errFetch := errors.New("fetch error")
+	it := newPageIterator(1, 1, func(ctx context.Context, page int) ([]int, error) {
+		if page == 2 {
+			return nil, errFetch
+		}
+		return []int{page}, nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != errFetch {
+		t.Errorf("Iterator returned error %v, want %v", err, errFetch)
+	}
+	if want := []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator returned %v, want %v", got, want)
+	}
+	if it.Next(context.Background()) {
+		t.Errorf("Iterator.Next returned true after error")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// MuteTimingsService communicates with mute timing provisioning methods of the
+// Grafana API.
+type MuteTimingsService struct {
+	client *Client
+}
+
+// NewMuteTimingsService returns a new MuteTimingsService.
+func NewMuteTimingsService(client *Client) *MuteTimingsService {
+	return &MuteTimingsService{
+		client: client,
+	}
+}
+
+// ErrMuteTimingNotFound represents an error if mute timing not found.
+var ErrMuteTimingNotFound = errors.New("Mute timing not found")
+
+// List fetches all mute timings.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-mute-timings
+func (s *MuteTimingsService) List(ctx context.Context) ([]*grafana.MuteTiming, error) {
+	var timings []*grafana.MuteTiming
+	if err := doProvisioning(ctx, s.client, "GET", "/api/v1/provisioning/mute-timings", nil, nil, &timings, nil); err != nil {
+		return nil, err
+	}
+
+	return timings, nil
+}
+
+// Get fetches mute timing with given name.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-mute-timing
+func (s *MuteTimingsService) Get(ctx context.Context, name string) (*grafana.MuteTiming, error) {
+	var timing grafana.MuteTiming
+	if err := doProvisioning(ctx, s.client, "GET", muteTimingURL(name), nil, nil, &timing, ErrMuteTimingNotFound); err != nil {
+		return nil, err
+	}
+
+	return &timing, nil
+}
+
+// Create creates a new mute timing and returns it as it's saved.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-post-mute-timing
+func (s *MuteTimingsService) Create(ctx context.Context, timing *grafana.MuteTiming, opt *ProvisioningOptions) (*grafana.MuteTiming, error) {
+	var created grafana.MuteTiming
+	if err := doProvisioning(ctx, s.client, "POST", "/api/v1/provisioning/mute-timings", opt, timing, &created, nil); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update replaces time intervals of mute timing with name of given one and
+// returns it as it's saved.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-put-mute-timing
+func (s *MuteTimingsService) Update(ctx context.Context, timing *grafana.MuteTiming, opt *ProvisioningOptions) (*grafana.MuteTiming, error) {
+	var updated grafana.MuteTiming
+	if err := doProvisioning(ctx, s.client, "PUT", muteTimingURL(timing.Name), opt, timing, &updated, ErrMuteTimingNotFound); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// Delete deletes mute timing with given name. Mute timing used by notification
+// policies can't be deleted.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-delete-mute-timing
+func (s *MuteTimingsService) Delete(ctx context.Context, name string) error {
+	return doProvisioning(ctx, s.client, "DELETE", muteTimingURL(name), nil, nil, nil, ErrMuteTimingNotFound)
+}
+
+func muteTimingURL(name string) string {
+	return "/api/v1/provisioning/mute-timings/" + url.PathEscape(name)
+}
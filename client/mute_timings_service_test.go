@@ -0,0 +1,68 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestMuteTimingsService_Get(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/v1/provisioning/mute-timings/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.EscapedPath() != "/api/v1/provisioning/mute-timings/out%20of%20hours" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"name": "out of hours", "time_intervals": [
+			{"times": [{"start_time": "18:00", "end_time": "24:00"}], "weekdays": ["monday:friday"], "location": "Europe/London"},
+			{"weekdays": ["saturday", "sunday"]}
+		]}`)
+	})
+
+	timing, err := client.MuteTimings.Get(context.Background(), "out of hours")
+	if err != nil {
+		t.Fatalf("MuteTimings.Get returned error: %v", err)
+	}
+	want := &grafana.MuteTiming{
+		Name: "out of hours",
+		TimeIntervals: []grafana.TimeInterval{
+			{
+				Times:    []grafana.TimeOfDayRange{{StartTime: "18:00", EndTime: "24:00"}},
+				Weekdays: []string{"monday:friday"},
+				Location: "Europe/London",
+			},
+			{Weekdays: []string{"saturday", "sunday"}},
+		},
+	}
+	if !reflect.DeepEqual(timing, want) {
+		t.Errorf("MuteTimings.Get returned %+v, want %+v", timing, want)
+	}
+
+	if _, err := client.MuteTimings.Get(context.Background(), "unknown"); err != ErrMuteTimingNotFound {
+		t.Errorf("MuteTimings.Get returned error %v, want %v", err, ErrMuteTimingNotFound)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// NotificationPoliciesService communicates with notification policy
+// provisioning methods of the Grafana API. Policies form a single tree that is
+// fetched and replaced as a whole.
+type NotificationPoliciesService struct {
+	client *Client
+}
+
+// NewNotificationPoliciesService returns a new NotificationPoliciesService.
+func NewNotificationPoliciesService(client *Client) *NotificationPoliciesService {
+	return &NotificationPoliciesService{
+		client: client,
+	}
+}
+
+// Get fetches the tree of notification policies.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-policy-tree
+func (s *NotificationPoliciesService) Get(ctx context.Context) (*grafana.NotificationPolicy, error) {
+	var policy grafana.NotificationPolicy
+	if err := doProvisioning(ctx, s.client, "GET", "/api/v1/provisioning/policies", nil, nil, &policy, nil); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Replace replaces the tree of notification policies with the given one.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-put-policy-tree
+func (s *NotificationPoliciesService) Replace(ctx context.Context, policy *grafana.NotificationPolicy, opt *ProvisioningOptions) error {
+	return doProvisioning(ctx, s.client, "PUT", "/api/v1/provisioning/policies", opt, policy, nil, nil)
+}
+
+// Reset resets the tree of notification policies to the default one and
+// returns it.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-reset-policy-tree
+func (s *NotificationPoliciesService) Reset(ctx context.Context) (*grafana.NotificationPolicy, error) {
+	var policy grafana.NotificationPolicy
+	if err := doProvisioning(ctx, s.client, "DELETE", "/api/v1/provisioning/policies", nil, nil, &policy, nil); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
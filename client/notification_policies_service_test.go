@@ -0,0 +1,61 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestNotificationPoliciesService_Replace(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/v1/provisioning/policies", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"receiver": "ops", "routes": [{"receiver": "oncall", "object_matchers": [["severity", "=", "critical"]]}]}`)
+		case "PUT":
+			if got := r.Header.Get("X-Disable-Provenance"); got != "true" {
+				t.Errorf("X-Disable-Provenance header: %q, want true", got)
+			}
+			testBody(t, r, `{"receiver":"ops","routes":[{"receiver":"oncall","object_matchers":[["severity","=","critical"]],"mute_time_intervals":["weekends"]}]}`+"\n")
+			fmt.Fprint(w, `{"message": "policies updated"}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	policy, err := client.NotificationPolicies.Get(ctx)
+	if err != nil {
+		t.Fatalf("NotificationPolicies.Get returned error: %v", err)
+	}
+	if len(policy.Routes) != 1 || policy.Routes[0].ObjectMatchers[0] != (grafana.Matcher{Label: "severity", Type: grafana.EqualMatch, Value: "critical"}) {
+		t.Fatalf("NotificationPolicies.Get returned unexpected policy %+v", policy)
+	}
+
+	policy.Routes[0].MuteTimeIntervals = []string{"weekends"}
+	if err := client.NotificationPolicies.Replace(ctx, policy, &ProvisioningOptions{DisableProvenance: true}); err != nil {
+		t.Errorf("NotificationPolicies.Replace returned error: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// NotificationTemplatesService communicates with notification template
+// provisioning methods of the Grafana API.
+type NotificationTemplatesService struct {
+	client *Client
+}
+
+// NewNotificationTemplatesService returns a new NotificationTemplatesService.
+func NewNotificationTemplatesService(client *Client) *NotificationTemplatesService {
+	return &NotificationTemplatesService{
+		client: client,
+	}
+}
+
+// ErrNotificationTemplateNotFound represents an error if notification template
+// not found.
+var ErrNotificationTemplateNotFound = errors.New("Notification template not found")
+
+// List fetches all notification templates.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-templates
+func (s *NotificationTemplatesService) List(ctx context.Context) ([]*grafana.NotificationTemplate, error) {
+	var templates []*grafana.NotificationTemplate
+	if err := doProvisioning(ctx, s.client, "GET", "/api/v1/provisioning/templates", nil, nil, &templates, nil); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// Get fetches notification template with given name.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-get-template
+func (s *NotificationTemplatesService) Get(ctx context.Context, name string) (*grafana.NotificationTemplate, error) {
+	var template grafana.NotificationTemplate
+	if err := doProvisioning(ctx, s.client, "GET", notificationTemplateURL(name), nil, nil, &template, ErrNotificationTemplateNotFound); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// Save creates or updates notification template and returns it as it's saved.
+// If template has Version, update fails when the stored template has another
+// one.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-put-template
+func (s *NotificationTemplatesService) Save(ctx context.Context, template *grafana.NotificationTemplate, opt *ProvisioningOptions) (*grafana.NotificationTemplate, error) {
+	body := struct {
+		Template string `json:"template"`
+		Version  string `json:"version,omitempty"`
+	}{template.Template, template.Version}
+
+	var saved grafana.NotificationTemplate
+	if err := doProvisioning(ctx, s.client, "PUT", notificationTemplateURL(template.Name), opt, body, &saved, nil); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Delete deletes notification template with given name.
+//
+// Grafana API docs: http://docs.grafana.org/developers/http_api/alerting_provisioning/#route-delete-template
+func (s *NotificationTemplatesService) Delete(ctx context.Context, name string) error {
+	return doProvisioning(ctx, s.client, "DELETE", notificationTemplateURL(name), nil, nil, nil, ErrNotificationTemplateNotFound)
+}
+
+func notificationTemplateURL(name string) string {
+	return "/api/v1/provisioning/templates/" + url.PathEscape(name)
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestNotificationTemplatesService_Save(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/v1/provisioning/templates/slack.title", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"template":"{{ define \"slack.title\" }}{{ .Status }}{{ end }}","version":"1"}`+"\n")
+		fmt.Fprint(w, `{"name": "slack.title", "template": "{{ define \"slack.title\" }}{{ .Status }}{{ end }}", "version": "2"}`)
+	})
+
+	template := &grafana.NotificationTemplate{
+		Name:     "slack.title",
+		Template: `{{ define "slack.title" }}{{ .Status }}{{ end }}`,
+		Version:  "1",
+	}
+	saved, err := client.NotificationTemplates.Save(context.Background(), template, nil)
+	if err != nil {
+		t.Fatalf("NotificationTemplates.Save returned error: %v", err)
+	}
+	if saved.Version != "2" {
+		t.Errorf("NotificationTemplates.Save returned version %q, want 2", saved.Version)
+	}
+}
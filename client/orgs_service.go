@@ -0,0 +1,349 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// OrgsService communicates with organization methods of the Grafana API.
+//
+// Methods working with an organization given by id require basic
+// authentication of a Grafana server admin, while methods working with the
+// current organization use organization of the API token or the user.
+type OrgsService struct {
+	client *Client
+}
+
+// NewOrgsService returns a new OrgsService.
+func NewOrgsService(client *Client) *OrgsService {
+	return &OrgsService{
+		client: client,
+	}
+}
+
+// ErrOrgNotFound represents an error if organization not found.
+var ErrOrgNotFound = errors.New("Organization not found")
+
+// ErrOrgNameTaken represents an error if organization with the same name
+// already exists.
+var ErrOrgNameTaken = errors.New("Organization name taken")
+
+// ErrOrgUserNotFound represents an error if user not found or isn't a member of
+// organization.
+var ErrOrgUserNotFound = errors.New("Organization user not found")
+
+// GetCurrent fetches the current organization.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#get-current-organization
+func (s *OrgsService) GetCurrent(ctx context.Context) (*grafana.Org, error) {
+	return s.get(ctx, "/api/org")
+}
+
+// UpdateCurrent renames the current organization.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#update-current-organization
+func (s *OrgsService) UpdateCurrent(ctx context.Context, name string) error {
+	return s.update(ctx, "/api/org", name)
+}
+
+// UpdateCurrentAddress updates address of the current organization.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#update-current-organization-address
+func (s *OrgsService) UpdateCurrentAddress(ctx context.Context, address *grafana.OrgAddress) error {
+	return s.do(ctx, "PUT", "/api/org/address", address)
+}
+
+// CurrentPreferences fetches UI preferences of the current organization.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/preferences/#get-current-org-prefs
+func (s *OrgsService) CurrentPreferences(ctx context.Context) (*grafana.Preferences, error) {
+	req, err := s.client.NewRequest(ctx, "GET", "/api/org/preferences", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefs grafana.Preferences
+	if _, err := s.client.Do(req, &prefs); err != nil {
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+// UpdateCurrentPreferences updates UI preferences of the current organization.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/preferences/#update-current-org-prefs
+func (s *OrgsService) UpdateCurrentPreferences(ctx context.Context, prefs *grafana.Preferences) error {
+	return s.do(ctx, "PUT", "/api/org/preferences", prefs)
+}
+
+// PatchCurrentPreferences updates non-empty UI preferences of the current
+// organization, the other ones are left intact.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/preferences/#patch-current-org-prefs
+func (s *OrgsService) PatchCurrentPreferences(ctx context.Context, prefs *grafana.Preferences) error {
+	return s.do(ctx, "PATCH", "/api/org/preferences", preferencesPatch(*prefs))
+}
+
+// GetByID fetches organization by given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#get-organization-by-id
+func (s *OrgsService) GetByID(ctx context.Context, id grafana.OrgID) (*grafana.Org, error) {
+	return s.get(ctx, fmt.Sprintf("/api/orgs/%d", id))
+}
+
+// GetByName fetches organization with given name.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#get-organization-by-name
+func (s *OrgsService) GetByName(ctx context.Context, name string) (*grafana.Org, error) {
+	if name == "" {
+		return nil, errors.New("Name cannot be empty")
+	}
+
+	return s.get(ctx, "/api/orgs/name/"+url.PathEscape(name))
+}
+
+func (s *OrgsService) get(ctx context.Context, u string) (*grafana.Org, error) {
+	req, err := s.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var org grafana.Org
+	if resp, err := s.client.Do(req, &org); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrOrgNotFound
+		}
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+// Create creates a new organization with given name and returns its id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#create-organization
+func (s *OrgsService) Create(ctx context.Context, name string) (grafana.OrgID, error) {
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+	req, err := s.client.NewRequest(ctx, "POST", "/api/orgs", body)
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		OrgID grafana.OrgID `json:"orgId"`
+	}
+	if resp, err := s.client.Do(req, &result); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return 0, ErrOrgNameTaken
+		}
+		return 0, err
+	}
+
+	return result.OrgID, nil
+}
+
+// Update renames organization with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#update-organization
+func (s *OrgsService) Update(ctx context.Context, id grafana.OrgID, name string) error {
+	return s.update(ctx, fmt.Sprintf("/api/orgs/%d", id), name)
+}
+
+// UpdateAddress updates address of organization with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#update-organization
+func (s *OrgsService) UpdateAddress(ctx context.Context, id grafana.OrgID, address *grafana.OrgAddress) error {
+	return s.do(ctx, "PUT", fmt.Sprintf("/api/orgs/%d/address", id), address)
+}
+
+func (s *OrgsService) update(ctx context.Context, u string, name string) error {
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+	err := s.do(ctx, "PUT", u, body)
+	if err, ok := err.(*ErrorResponse); ok && err.Response.StatusCode == http.StatusConflict {
+		return ErrOrgNameTaken
+	}
+	return err
+}
+
+// do sends an API request with given body. It returns ErrOrgNotFound if the
+// API responds with 404.
+func (s *OrgsService) do(ctx context.Context, method, u string, body interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, nil); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrOrgNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete deletes organization with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#delete-organization
+func (s *OrgsService) Delete(ctx context.Context, id grafana.OrgID) error {
+	u := fmt.Sprintf("/api/orgs/%d", id)
+	req, err := s.client.NewRequest(ctx, "DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, nil); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrOrgNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// OrgListOptions specifies the optional parameters to the OrgsService.List
+// method.
+type OrgListOptions struct {
+	Query   string `url:"query,omitempty"` // part of organization name
+	Name    string `url:"name,omitempty"`  // exact organization name
+	Page    int    `url:"page,omitempty"`
+	PerPage int    `url:"perpage,omitempty"`
+}
+
+// List fetches organizations matching given options.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#search-all-organizations
+func (s *OrgsService) List(ctx context.Context, opt *OrgListOptions) ([]*grafana.Org, error) {
+	u, err := addOptions("/api/orgs", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgs []*grafana.Org
+	if _, err := s.client.Do(req, &orgs); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// defaultOrgsPerPage is a number of organizations per page that Grafana uses if
+// it isn't given.
+const defaultOrgsPerPage = 1000
+
+// ListAll returns iterator over all organizations matching given options.
+// Results are fetched page by page starting from opt.Page.
+func (s *OrgsService) ListAll(opt *OrgListOptions) *Iterator[*grafana.Org] {
+	pageOpt := OrgListOptions{}
+	if opt != nil {
+		pageOpt = *opt
+	}
+	if pageOpt.PerPage <= 0 {
+		pageOpt.PerPage = defaultOrgsPerPage
+	}
+
+	return newPageIterator(pageOpt.Page, pageOpt.PerPage, func(ctx context.Context, page int) ([]*grafana.Org, error) {
+		pageOpt.Page = page
+		return s.List(ctx, &pageOpt)
+	})
+}
+
+// Users fetches members of organization with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#get-users-in-organization
+func (s *OrgsService) Users(ctx context.Context, id grafana.OrgID) ([]*grafana.OrgUser, error) {
+	u := fmt.Sprintf("/api/orgs/%d/users", id)
+	req, err := s.client.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*grafana.OrgUser
+	if resp, err := s.client.Do(req, &users); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrOrgNotFound
+		}
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// AddUser adds existing user with given login or email to organization with
+// given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#add-user-in-organization
+func (s *OrgsService) AddUser(ctx context.Context, id grafana.OrgID, loginOrEmail string, role grafana.OrgRole) error {
+	u := fmt.Sprintf("/api/orgs/%d/users", id)
+	body := struct {
+		LoginOrEmail string          `json:"loginOrEmail"`
+		Role         grafana.OrgRole `json:"role"`
+	}{loginOrEmail, role}
+	return s.userRequest(ctx, "POST", u, body)
+}
+
+// UpdateUserRole changes role of user with given id in organization with given
+// id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#update-users-in-organization
+func (s *OrgsService) UpdateUserRole(ctx context.Context, id grafana.OrgID, userID grafana.UserID, role grafana.OrgRole) error {
+	u := fmt.Sprintf("/api/orgs/%d/users/%d", id, userID)
+	body := struct {
+		Role grafana.OrgRole `json:"role"`
+	}{role}
+	return s.userRequest(ctx, "PATCH", u, body)
+}
+
+// RemoveUser removes user with given id from organization with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/org/#delete-user-in-organization
+func (s *OrgsService) RemoveUser(ctx context.Context, id grafana.OrgID, userID grafana.UserID) error {
+	u := fmt.Sprintf("/api/orgs/%d/users/%d", id, userID)
+	return s.userRequest(ctx, "DELETE", u, nil)
+}
+
+func (s *OrgsService) userRequest(ctx context.Context, method, u string, body interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, nil); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrOrgUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
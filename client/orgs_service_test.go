@@ -0,0 +1,194 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestOrgsService_GetByName(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/orgs/name/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.EscapedPath() != "/api/orgs/name/Main%20Org." {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "Main Org.", "address": {"address1": "", "address2": "", "city": "London", "zipCode": "", "state": "", "country": "UK"}}`)
+	})
+
+	org, err := client.Orgs.GetByName(context.Background(), "Main Org.")
+	if err != nil {
+		t.Fatalf("Orgs.GetByName returned error: %v", err)
+	}
+	want := &grafana.Org{ID: 1, Name: "Main Org.", Address: &grafana.OrgAddress{City: "London", Country: "UK"}}
+	if !reflect.DeepEqual(org, want) {
+		t.Errorf("Orgs.GetByName returned %+v, want %+v", org, want)
+	}
+
+	if _, err := client.Orgs.GetByName(context.Background(), "Unknown"); err != ErrOrgNotFound {
+		t.Errorf("Orgs.GetByName returned error %v, want %v", err, ErrOrgNotFound)
+	}
+}
+
+func TestOrgsService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/orgs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Decoding request body returned error %s", err)
+		}
+		if body.Name == "Taken" {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message": "Organization name taken"}`)
+			return
+		}
+		fmt.Fprint(w, `{"orgId": 6, "message": "Organization created"}`)
+	})
+
+	id, err := client.Orgs.Create(context.Background(), "Customer")
+	if err != nil {
+		t.Fatalf("Orgs.Create returned error: %v", err)
+	}
+	if id != 6 {
+		t.Errorf("Orgs.Create returned id %d, want 6", id)
+	}
+
+	if _, err := client.Orgs.Create(context.Background(), "Taken"); err != ErrOrgNameTaken {
+		t.Errorf("Orgs.Create returned error %v, want %v", err, ErrOrgNameTaken)
+	}
+}
+
+func TestOrgsService_ListAll(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/orgs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			testFormValues(t, r, url.Values{"query": {"cust"}, "page": {"1"}, "perpage": {"2"}})
+			fmt.Fprint(w, `[{"id": 2, "name": "Customer 1"}, {"id": 3, "name": "Customer 2"}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id": 4, "name": "Customer 3"}]`)
+		default:
+			t.Errorf("Unexpected page requested: %s", r.URL.RawQuery)
+		}
+	})
+
+	it := client.Orgs.ListAll(&OrgListOptions{Query: "cust", PerPage: 2})
+	var ids []grafana.OrgID
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Orgs.ListAll returned error: %v", err)
+	}
+	if want := []grafana.OrgID{2, 3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Orgs.ListAll returned %v, want %v", ids, want)
+	}
+}
+
+func TestOrgsService_Users(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/orgs/2/users", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"orgId": 2, "userId": 1, "email": "admin@localhost", "login": "admin", "role": "Admin"}]`)
+		case "POST":
+			testBody(t, r, `{"loginOrEmail":"user@localhost","role":"Viewer"}`+"\n")
+			fmt.Fprint(w, `{"message": "User added to organization"}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/api/orgs/2/users/5", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			testBody(t, r, `{"role":"Editor"}`+"\n")
+			fmt.Fprint(w, `{"message": "Organization user updated"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"message": "User removed from organization"}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	users, err := client.Orgs.Users(ctx, 2)
+	if err != nil {
+		t.Fatalf("Orgs.Users returned error: %v", err)
+	}
+	want := []*grafana.OrgUser{{OrgID: 2, UserID: 1, Email: "admin@localhost", Login: "admin", Role: grafana.AdminOrgRole}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Orgs.Users returned %+v, want %+v", users, want)
+	}
+
+	if err := client.Orgs.AddUser(ctx, 2, "user@localhost", grafana.ViewerOrgRole); err != nil {
+		t.Errorf("Orgs.AddUser returned error: %v", err)
+	}
+	if err := client.Orgs.UpdateUserRole(ctx, 2, 5, grafana.EditorOrgRole); err != nil {
+		t.Errorf("Orgs.UpdateUserRole returned error: %v", err)
+	}
+	if err := client.Orgs.RemoveUser(ctx, 2, 5); err != nil {
+		t.Errorf("Orgs.RemoveUser returned error: %v", err)
+	}
+	if err := client.Orgs.RemoveUser(ctx, 2, 6); err != ErrOrgUserNotFound {
+		t.Errorf("Orgs.RemoveUser returned error %v, want %v", err, ErrOrgUserNotFound)
+	}
+}
+
+func TestOrgsService_PatchCurrentPreferences(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/org/preferences", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"homeDashboardUID":"ops","locale":"en-GB"}`+"\n")
+		fmt.Fprint(w, `{"message": "Preferences updated"}`)
+	})
+
+	prefs := &grafana.Preferences{HomeDashboardUID: "ops", Locale: "en-GB"}
+	if err := client.Orgs.PatchCurrentPreferences(context.Background(), prefs); err != nil {
+		t.Errorf("Orgs.PatchCurrentPreferences returned error: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// getPermissions fetches access control list from u. It returns notFound if
+// the API responds with 404.
+func getPermissions(ctx context.Context, c *Client, u string, notFound error) ([]*grafana.Permission, error) {
+	req, err := c.NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var perms []*grafana.Permission
+	if resp, err := c.Do(req, &perms); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, notFound
+		}
+		return nil, err
+	}
+
+	return perms, nil
+}
+
+// permissionItem is an entry of access control list sent on update.
+type permissionItem struct {
+	Role       grafana.OrgRole         `json:"role,omitempty"`
+	TeamID     grafana.TeamID          `json:"teamId,omitempty"`
+	UserID     grafana.UserID          `json:"userId,omitempty"`
+	Permission grafana.PermissionLevel `json:"permission"`
+}
+
+// updatePermissions replaces access control list at u with perms. Inherited
+// permissions are skipped since they belong to the parent folder. It returns
+// notFound if the API responds with 404.
+func updatePermissions(ctx context.Context, c *Client, u string, perms []*grafana.Permission, notFound error) error {
+	body := struct {
+		Items []permissionItem `json:"items"`
+	}{Items: []permissionItem{}}
+	for _, p := range perms {
+		if p.Inherited {
+			continue
+		}
+		body.Items = append(body.Items, permissionItem{p.Role, p.TeamID, p.UserID, p.Permission})
+	}
+
+	req, err := c.NewRequest(ctx, "POST", u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := c.Do(req, nil); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return notFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// addPermission adds p to access control list at u replacing an entry for the
+// same subject if any.
+//
+// Grafana doesn't support atomic updates of access control lists, so changes
+// made by others between fetching and updating the list are lost.
+func addPermission(ctx context.Context, c *Client, u string, p *grafana.Permission, notFound error) error {
+	perms, err := getPermissions(ctx, c, u, notFound)
+	if err != nil {
+		return err
+	}
+
+	added := false
+	for i, existing := range perms {
+		if !existing.Inherited && existing.SameSubject(p) {
+			perms[i] = p
+			added = true
+		}
+	}
+	if !added {
+		perms = append(perms, p)
+	}
+
+	return updatePermissions(ctx, c, u, perms, notFound)
+}
+
+// removePermission removes entry for the subject of p from access control list
+// at u. The list isn't updated if there is no such entry.
+//
+// Grafana doesn't support atomic updates of access control lists, so changes
+// made by others between fetching and updating the list are lost.
+func removePermission(ctx context.Context, c *Client, u string, p *grafana.Permission, notFound error) error {
+	perms, err := getPermissions(ctx, c, u, notFound)
+	if err != nil {
+		return err
+	}
+
+	kept := perms[:0]
+	for _, existing := range perms {
+		if existing.Inherited || !existing.SameSubject(p) {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) == len(perms) {
+		return nil
+	}
+
+	return updatePermissions(ctx, c, u, kept, notFound)
+}
@@ -0,0 +1,218 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// PlaylistsService communicates with playlist methods of the Grafana API.
+//
+// Grafana before 9.0 identifies playlists by ID and the newer versions by UID,
+// so methods come in pairs, e.g. Get and GetByUID. Update uses UID if it's
+// set and ID otherwise.
+type PlaylistsService struct {
+	client *Client
+}
+
+// NewPlaylistsService returns a new PlaylistsService.
+func NewPlaylistsService(client *Client) *PlaylistsService {
+	return &PlaylistsService{
+		client: client,
+	}
+}
+
+// ErrPlaylistNotFound represents an error if playlist not found.
+var ErrPlaylistNotFound = errors.New("Playlist not found")
+
+// PlaylistSearchOptions specifies the optional parameters to the
+// PlaylistsService.Search method.
+type PlaylistSearchOptions struct {
+	Query string `url:"query,omitempty"` // part of playlist name
+	Limit int    `url:"limit,omitempty"`
+}
+
+// Search fetches playlists found with given criteria. Found playlists don't
+// have items.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#search-playlist
+func (s *PlaylistsService) Search(ctx context.Context, opt *PlaylistSearchOptions) ([]*grafana.Playlist, error) {
+	u, err := addOptions("/api/playlists", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var playlists []*grafana.Playlist
+	if err := s.do(ctx, "GET", u, nil, &playlists); err != nil {
+		return nil, err
+	}
+
+	return playlists, nil
+}
+
+// Get fetches playlist with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#get-one-playlist
+func (s *PlaylistsService) Get(ctx context.Context, id grafana.PlaylistID) (*grafana.Playlist, error) {
+	return s.get(ctx, playlistURL(id))
+}
+
+// GetByUID fetches playlist with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#get-one-playlist
+func (s *PlaylistsService) GetByUID(ctx context.Context, uid string) (*grafana.Playlist, error) {
+	return s.get(ctx, playlistURLByUID(uid))
+}
+
+func (s *PlaylistsService) get(ctx context.Context, u string) (*grafana.Playlist, error) {
+	var playlist grafana.Playlist
+	if err := s.do(ctx, "GET", u, nil, &playlist); err != nil {
+		return nil, err
+	}
+
+	return &playlist, nil
+}
+
+// Items fetches items of playlist with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#get-playlist-items
+func (s *PlaylistsService) Items(ctx context.Context, id grafana.PlaylistID) ([]grafana.PlaylistItem, error) {
+	return s.items(ctx, playlistURL(id))
+}
+
+// ItemsByUID fetches items of playlist with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#get-playlist-items
+func (s *PlaylistsService) ItemsByUID(ctx context.Context, uid string) ([]grafana.PlaylistItem, error) {
+	return s.items(ctx, playlistURLByUID(uid))
+}
+
+func (s *PlaylistsService) items(ctx context.Context, u string) ([]grafana.PlaylistItem, error) {
+	var items []grafana.PlaylistItem
+	if err := s.do(ctx, "GET", u+"/items", nil, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// PlaylistDashboard is a dashboard shown by playlist, see
+// PlaylistsService.Dashboards.
+type PlaylistDashboard struct {
+	ID    grafana.DashboardID `json:"id"`
+	UID   string              `json:"uid,omitempty"`
+	Slug  string              `json:"slug"`
+	Title string              `json:"title"`
+	URI   string              `json:"uri"` // deprecated, ie. db/slug
+	URL   string              `json:"url"`
+	Order int                 `json:"order"`
+}
+
+// Dashboards fetches dashboards shown by playlist with given id in order of
+// showing them, with tags of its items resolved.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#get-playlist-dashboards
+func (s *PlaylistsService) Dashboards(ctx context.Context, id grafana.PlaylistID) ([]*PlaylistDashboard, error) {
+	return s.dashboards(ctx, playlistURL(id))
+}
+
+// DashboardsByUID fetches dashboards shown by playlist with given uid in order
+// of showing them, with tags of its items resolved.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#get-playlist-dashboards
+func (s *PlaylistsService) DashboardsByUID(ctx context.Context, uid string) ([]*PlaylistDashboard, error) {
+	return s.dashboards(ctx, playlistURLByUID(uid))
+}
+
+func (s *PlaylistsService) dashboards(ctx context.Context, u string) ([]*PlaylistDashboard, error) {
+	var dashboards []*PlaylistDashboard
+	if err := s.do(ctx, "GET", u+"/dashboards", nil, &dashboards); err != nil {
+		return nil, err
+	}
+
+	return dashboards, nil
+}
+
+// Create creates a new playlist and sets ID and UID of given one. UID is set
+// by Grafana 9.0 and later only.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#create-a-playlist
+func (s *PlaylistsService) Create(ctx context.Context, playlist *grafana.Playlist) error {
+	var created grafana.Playlist
+	if err := s.do(ctx, "POST", "/api/playlists", playlist, &created); err != nil {
+		return err
+	}
+
+	playlist.ID = created.ID
+	playlist.UID = created.UID
+	return nil
+}
+
+// Update replaces playlist with uid of given one, or with its id if uid isn't
+// set.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#update-a-playlist
+func (s *PlaylistsService) Update(ctx context.Context, playlist *grafana.Playlist) error {
+	u := playlistURL(playlist.ID)
+	if playlist.UID != "" {
+		u = playlistURLByUID(playlist.UID)
+	}
+	return s.do(ctx, "PUT", u, playlist, nil)
+}
+
+// Delete deletes playlist with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#delete-a-playlist
+func (s *PlaylistsService) Delete(ctx context.Context, id grafana.PlaylistID) error {
+	return s.do(ctx, "DELETE", playlistURL(id), nil, nil)
+}
+
+// DeleteByUID deletes playlist with given uid.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/playlist/#delete-a-playlist
+func (s *PlaylistsService) DeleteByUID(ctx context.Context, uid string) error {
+	return s.do(ctx, "DELETE", playlistURLByUID(uid), nil, nil)
+}
+
+func playlistURL(id grafana.PlaylistID) string {
+	return fmt.Sprintf("/api/playlists/%d", id)
+}
+
+func playlistURLByUID(uid string) string {
+	return "/api/playlists/" + url.PathEscape(uid)
+}
+
+// do sends an API request and decodes the response into v if it's not nil.
+// It returns ErrPlaylistNotFound if the API responds with 404.
+func (s *PlaylistsService) do(ctx context.Context, method, u string, body, v interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, v); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+
+	return nil
+}
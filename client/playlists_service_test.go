@@ -0,0 +1,124 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestPlaylistsService_Search(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/playlists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, url.Values{"query": {"office"}, "limit": {"10"}})
+		fmt.Fprint(w, `[{"id": 1, "uid": "office-tv", "name": "office tv", "interval": "5m"}]`)
+	})
+
+	playlists, err := client.Playlists.Search(context.Background(), &PlaylistSearchOptions{Query: "office", Limit: 10})
+	if err != nil {
+		t.Fatalf("Playlists.Search returned error: %v", err)
+	}
+
+	want := []*grafana.Playlist{{ID: 1, UID: "office-tv", Name: "office tv", Interval: "5m"}}
+	if !reflect.DeepEqual(playlists, want) {
+		t.Errorf("Playlists.Search returned %+v, want %+v", playlists, want)
+	}
+}
+
+func TestPlaylistsService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/playlists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"office tv","interval":"1m","items":[{"type":"dashboard_by_tag","value":"tv","order":1}]}`+"\n")
+		fmt.Fprint(w, `{"id": 2, "uid": "office-tv", "name": "office tv", "interval": "1m"}`)
+	})
+
+	playlist := grafana.NewPlaylist("office tv", "1m")
+	playlist.AddTag("tv")
+	if err := client.Playlists.Create(context.Background(), playlist); err != nil {
+		t.Fatalf("Playlists.Create returned error: %v", err)
+	}
+	if playlist.ID != 2 || playlist.UID != "office-tv" {
+		t.Errorf("Playlists.Create set id %d and uid %q, want 2 and office-tv", playlist.ID, playlist.UID)
+	}
+}
+
+func TestPlaylistsService_Update(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	var paths []string
+	mux.HandleFunc("/api/playlists/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	})
+
+	ctx := context.Background()
+	if err := client.Playlists.Update(ctx, &grafana.Playlist{ID: 3, Name: "legacy"}); err != nil {
+		t.Fatalf("Playlists.Update returned error: %v", err)
+	}
+	if err := client.Playlists.Update(ctx, &grafana.Playlist{ID: 3, UID: "office-tv", Name: "office tv"}); err != nil {
+		t.Fatalf("Playlists.Update returned error: %v", err)
+	}
+
+	if want := []string{"/api/playlists/3", "/api/playlists/office-tv"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("Playlists.Update requested %v, want %v", paths, want)
+	}
+}
+
+func TestPlaylistsService_DashboardsByUID(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/playlists/office-tv/dashboards", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id": 5, "uid": "ops", "slug": "ops", "title": "Ops", "uri": "db/ops", "url": "/d/ops/ops", "order": 1}]`)
+	})
+
+	ctx := context.Background()
+	dashboards, err := client.Playlists.DashboardsByUID(ctx, "office-tv")
+	if err != nil {
+		t.Fatalf("Playlists.DashboardsByUID returned error: %v", err)
+	}
+
+	want := []*PlaylistDashboard{{ID: 5, UID: "ops", Slug: "ops", Title: "Ops", URI: "db/ops", URL: "/d/ops/ops", Order: 1}}
+	if !reflect.DeepEqual(dashboards, want) {
+		t.Errorf("Playlists.DashboardsByUID returned %+v, want %+v", dashboards, want)
+	}
+
+	if _, err := client.Playlists.DashboardsByUID(ctx, "unknown"); err != ErrPlaylistNotFound {
+		t.Errorf("Playlists.DashboardsByUID returned error %v, want %v", err, ErrPlaylistNotFound)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "github.com/utilitywarehouse/go-grafana/grafana"
+
+// preferencesPatch is a body of requests patching UI preferences. Unlike
+// grafana.Preferences, it omits empty fields, so that they are left intact.
+type preferencesPatch struct {
+	Theme            string              `json:"theme,omitempty"`
+	HomeDashboardID  grafana.DashboardID `json:"homeDashboardId,omitempty"`
+	HomeDashboardUID string              `json:"homeDashboardUID,omitempty"`
+	Timezone         string              `json:"timezone,omitempty"`
+	WeekStart        string              `json:"weekStart,omitempty"`
+	Locale           string              `json:"locale,omitempty"`
+}
@@ -0,0 +1,228 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// SnapshotsService communicates with dashboard snapshot methods of the
+// Grafana API.
+type SnapshotsService struct {
+	client *Client
+}
+
+// NewSnapshotsService returns a new SnapshotsService.
+func NewSnapshotsService(client *Client) *SnapshotsService {
+	return &SnapshotsService{
+		client: client,
+	}
+}
+
+// ErrSnapshotNotFound represents an error if snapshot not found.
+var ErrSnapshotNotFound = errors.New("Snapshot not found")
+
+// SnapshotCreateOptions specifies the optional parameters to the
+// SnapshotsService.Create method.
+type SnapshotCreateOptions struct {
+	// Name is a name of snapshot. Title of dashboard is used if it's empty.
+	Name string
+	// Expires is a time after which snapshot is deleted. Snapshot never
+	// expires if it's zero.
+	Expires time.Duration
+	// External stores snapshot on external server configured in Grafana,
+	// e.g. snapshots.raintank.io.
+	External bool
+	// Key and DeleteKey are keys of snapshot. Grafana generates random keys
+	// if they're empty.
+	Key       string
+	DeleteKey string
+}
+
+type snapshotCreateRequest struct {
+	Dashboard map[string]interface{} `json:"dashboard"`
+	Name      string                 `json:"name,omitempty"`
+	Expires   int64                  `json:"expires,omitempty"` // in seconds
+	External  bool                   `json:"external"`
+	Key       string                 `json:"key,omitempty"`
+	DeleteKey string                 `json:"deleteKey,omitempty"`
+}
+
+// SnapshotCreateResult is a result of creating of a snapshot.
+type SnapshotCreateResult struct {
+	ID        grafana.SnapshotID `json:"id"`
+	Key       string             `json:"key"`
+	DeleteKey string             `json:"deleteKey"`
+	URL       string             `json:"url"`       // public link to snapshot
+	DeleteURL string             `json:"deleteUrl"` // link that deletes snapshot
+}
+
+// Create creates a snapshot of given dashboard. Panels are shown with their
+// snapshot data, see grafana.Dashboard.SetSnapshotData. Targets, datasources
+// and links of panels and links of the dashboard are removed from the copy of
+// it that is sent, the dashboard itself isn't changed. Annotations and template
+// variables are sent as they are.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/snapshot/#create-new-snapshot
+func (s *SnapshotsService) Create(ctx context.Context, dashboard *grafana.Dashboard, opt *SnapshotCreateOptions) (*SnapshotCreateResult, error) {
+	if opt == nil {
+		opt = &SnapshotCreateOptions{}
+	}
+
+	snapshot, err := snapshotDashboard(dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	sReq := snapshotCreateRequest{
+		Dashboard: snapshot,
+		Name:      opt.Name,
+		Expires:   int64(opt.Expires / time.Second),
+		External:  opt.External,
+		Key:       opt.Key,
+		DeleteKey: opt.DeleteKey,
+	}
+	var result SnapshotCreateResult
+	if err := s.do(ctx, "POST", "/api/snapshots", sReq, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// snapshotDashboard returns JSON object of dashboard without panels' targets,
+// datasources and links and dashboard's links, like Grafana's frontend does.
+func snapshotDashboard(dashboard *grafana.Dashboard) (map[string]interface{}, error) {
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+	var d map[string]interface{}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+
+	delete(d, "links")
+	stripSnapshotPanels(d["panels"])
+	if rows, ok := d["rows"].([]interface{}); ok {
+		for _, r := range rows {
+			if row, ok := r.(map[string]interface{}); ok {
+				stripSnapshotPanels(row["panels"])
+			}
+		}
+	}
+	return d, nil
+}
+
+// stripSnapshotPanels removes targets, datasources and links of panels,
+// including ones of collapsed rows.
+func stripSnapshotPanels(panels interface{}) {
+	list, ok := panels.([]interface{})
+	if !ok {
+		return
+	}
+	for _, p := range list {
+		if p, ok := p.(map[string]interface{}); ok {
+			delete(p, "targets")
+			delete(p, "datasource")
+			delete(p, "links")
+			stripSnapshotPanels(p["panels"])
+		}
+	}
+}
+
+// SnapshotListOptions specifies the optional parameters to the
+// SnapshotsService.List method.
+type SnapshotListOptions struct {
+	Query string `url:"query,omitempty"` // part of snapshot name
+	Limit int    `url:"limit,omitempty"`
+}
+
+// List fetches snapshots of the current organization found with given
+// criteria.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/snapshot/#get-list-of-snapshots
+func (s *SnapshotsService) List(ctx context.Context, opt *SnapshotListOptions) ([]*grafana.Snapshot, error) {
+	u, err := addOptions("/api/dashboard/snapshots", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*grafana.Snapshot
+	if err := s.do(ctx, "GET", u, nil, &snapshots); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// Get fetches dashboard of snapshot with given key. Meta of the dashboard has
+// expiration time of the snapshot.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/snapshot/#get-snapshot-by-key
+func (s *SnapshotsService) Get(ctx context.Context, key string) (*grafana.Dashboard, error) {
+	var sResp dashboardGetResponse
+	if err := s.do(ctx, "GET", "/api/snapshots/"+url.PathEscape(key), nil, &sResp); err != nil {
+		return nil, err
+	}
+
+	var d grafana.Dashboard
+	if len(sResp.Dashboard) > 0 {
+		if err := json.Unmarshal(sResp.Dashboard, &d); err != nil {
+			return nil, err
+		}
+	}
+	d.Meta = sResp.Meta
+	return &d, nil
+}
+
+// Delete deletes snapshot with given key.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/snapshot/#delete-snapshot-by-key
+func (s *SnapshotsService) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, "DELETE", "/api/snapshots/"+url.PathEscape(key), nil, nil)
+}
+
+// DeleteByDeleteKey deletes snapshot with given delete key. Unlike Delete, it
+// doesn't require authentication.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/snapshot/#delete-snapshot-by-deletekey
+func (s *SnapshotsService) DeleteByDeleteKey(ctx context.Context, deleteKey string) error {
+	return s.do(ctx, "GET", "/api/snapshots-delete/"+url.PathEscape(deleteKey), nil, nil)
+}
+
+// do sends an API request and decodes the response into v if it's not nil.
+// It returns ErrSnapshotNotFound if the API responds with 404.
+func (s *SnapshotsService) do(ctx context.Context, method, u string, body, v interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, v); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrSnapshotNotFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+)
+
+func TestSnapshotsService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body struct {
+			Dashboard struct {
+				Links  []interface{} `json:"links"`
+				Panels []struct {
+					Datasource   interface{}            `json:"datasource"`
+					Links        []interface{}          `json:"links"`
+					Targets      []interface{}          `json:"targets"`
+					SnapshotData []panel.SnapshotSeries `json:"snapshotData"`
+					Panels       []struct {
+						Datasource interface{}   `json:"datasource"`
+						Targets    []interface{} `json:"targets"`
+					} `json:"panels"`
+				} `json:"panels"`
+			} `json:"dashboard"`
+			Name     string `json:"name"`
+			Expires  int64  `json:"expires"`
+			External bool   `json:"external"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Request body: %v", err)
+		}
+		if body.Name != "incident" || body.Expires != 3600 || body.External {
+			t.Errorf("Request body: name %q, expires %d, external %t", body.Name, body.Expires, body.External)
+		}
+		if body.Dashboard.Links != nil {
+			t.Errorf("Request body: dashboard links %+v, want none", body.Dashboard.Links)
+		}
+		panels := body.Dashboard.Panels
+		if len(panels) != 2 || len(panels[0].SnapshotData) != 1 {
+			t.Fatalf("Request body: panels %+v, want a panel with snapshot data and a row", panels)
+		}
+		if p := panels[0]; p.Datasource != nil || p.Links != nil || p.Targets != nil {
+			t.Errorf("Request body: panel %+v, want no datasource, links and targets", p)
+		}
+		if nested := panels[1].Panels; len(nested) != 1 || nested[0].Datasource != nil || nested[0].Targets != nil {
+			t.Errorf("Request body: row's panels %+v, want one without datasource and targets", nested)
+		}
+		fmt.Fprint(w, `{"id": 1, "key": "abc", "deleteKey": "def", "url": "http://grafana/dashboard/snapshot/abc", "deleteUrl": "http://grafana/api/snapshots-delete/def"}`)
+	})
+
+	var d grafana.Dashboard
+	if err := json.Unmarshal([]byte(`{
+		"title": "Incident",
+		"links": [{"title": "Runbook", "type": "link", "url": "https://internal/runbook"}],
+		"panels": [
+			{"id": 1, "type": "graph", "gridPos": {"x": 0, "y": 0, "w": 24, "h": 8},
+			 "datasource": {"type": "prometheus", "uid": "prom"},
+			 "links": [{"title": "Logs", "type": "absolute", "url": "https://internal/logs"}],
+			 "targets": [{"refId": "A", "expr": "rate(errors_total[5m])"}]},
+			{"id": 2, "type": "row", "collapsed": true, "gridPos": {"x": 0, "y": 8, "w": 24, "h": 1},
+			 "panels": [{"id": 3, "type": "timeseries", "gridPos": {"x": 0, "y": 9, "w": 24, "h": 8},
+			  "datasource": {"type": "prometheus", "uid": "prom"},
+			  "targets": [{"refId": "A", "expr": "up"}]}]}
+		]
+	}`), &d); err != nil {
+		t.Fatalf("Dashboard.UnmarshalJSON returned error: %v", err)
+	}
+	series := panel.NewSnapshotSeries("errors")
+	series.Add(time.Unix(1500000000, 0), 3)
+	if err := d.SetSnapshotData(map[uint][]panel.SnapshotSeries{1: {series}}); err != nil {
+		t.Fatalf("Dashboard.SetSnapshotData returned error: %v", err)
+	}
+
+	result, err := client.Snapshots.Create(context.Background(), &d, &SnapshotCreateOptions{Name: "incident", Expires: time.Hour})
+	if err != nil {
+		t.Fatalf("Snapshots.Create returned error: %v", err)
+	}
+	if len(d.Links) != 1 {
+		t.Errorf("Snapshots.Create changed dashboard's links to %+v", d.Links)
+	}
+
+	want := &SnapshotCreateResult{
+		ID:        1,
+		Key:       "abc",
+		DeleteKey: "def",
+		URL:       "http://grafana/dashboard/snapshot/abc",
+		DeleteURL: "http://grafana/api/snapshots-delete/def",
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Snapshots.Create returned %+v, want %+v", result, want)
+	}
+}
+
+func TestSnapshotsService_Get(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/snapshots/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Path != "/api/snapshots/abc" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"dashboard": {"title": "Incident", "panels": [{"type": "graph", "id": 1, "snapshotData": [{"target": "errors", "datapoints": [[3, 1500000000000]]}]}]},
+			"meta": {"type": "snapshot", "expires": "2017-07-14T03:40:00Z"}}`)
+	})
+
+	ctx := context.Background()
+	d, err := client.Snapshots.Get(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Snapshots.Get returned error: %v", err)
+	}
+	if d.Title != "Incident" || d.Meta == nil || d.Meta.Type != "snapshot" {
+		t.Errorf("Snapshots.Get returned dashboard %q with meta %+v", d.Title, d.Meta)
+	}
+	if len(d.Panels) != 1 || len(d.Panels[0].GeneralOptions().SnapshotData) != 1 {
+		t.Errorf("Snapshots.Get returned panels without snapshot data: %+v", d.Panels)
+	}
+
+	if _, err := client.Snapshots.Get(ctx, "unknown"); err != ErrSnapshotNotFound {
+		t.Errorf("Snapshots.Get returned error %v, want %v", err, ErrSnapshotNotFound)
+	}
+}
+
+func TestSnapshotsService_DeleteByDeleteKey(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/snapshots-delete/def", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"message": "Snapshot deleted"}`)
+	})
+
+	if err := client.Snapshots.DeleteByDeleteKey(context.Background(), "def"); err != nil {
+		t.Errorf("Snapshots.DeleteByDeleteKey returned error: %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// TeamsService communicates with team methods of the Grafana API.
+type TeamsService struct {
+	client *Client
+}
+
+// NewTeamsService returns a new TeamsService.
+func NewTeamsService(client *Client) *TeamsService {
+	return &TeamsService{
+		client: client,
+	}
+}
+
+// ErrTeamNotFound represents an error if team not found.
+var ErrTeamNotFound = errors.New("Team not found")
+
+// ErrTeamNameTaken represents an error if team with the same name already
+// exists.
+var ErrTeamNameTaken = errors.New("Team name taken")
+
+// TeamSearchOptions specifies the optional parameters to the
+// TeamsService.Search method.
+type TeamSearchOptions struct {
+	Query   string `url:"query,omitempty"` // part of team name
+	Name    string `url:"name,omitempty"`  // exact team name
+	Page    int    `url:"page,omitempty"`
+	PerPage int    `url:"perpage,omitempty"`
+}
+
+// TeamSearchResult is a page of teams found by TeamsService.Search.
+type TeamSearchResult struct {
+	TotalCount int             `json:"totalCount"`
+	Teams      []*grafana.Team `json:"teams"`
+	Page       int             `json:"page"`
+	PerPage    int             `json:"perPage"`
+}
+
+// Search searches teams of the current organization with given criteria.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#team-search-with-paging
+func (s *TeamsService) Search(ctx context.Context, opt *TeamSearchOptions) (*TeamSearchResult, error) {
+	u, err := addOptions("/api/teams/search", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TeamSearchResult
+	if err := s.do(ctx, "GET", u, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// defaultTeamsPerPage is a number of teams per page that Grafana uses if it
+// isn't given.
+const defaultTeamsPerPage = 1000
+
+// SearchAll returns iterator over all teams found with given criteria. Results
+// are fetched page by page starting from opt.Page.
+func (s *TeamsService) SearchAll(opt *TeamSearchOptions) *Iterator[*grafana.Team] {
+	pageOpt := TeamSearchOptions{}
+	if opt != nil {
+		pageOpt = *opt
+	}
+	if pageOpt.PerPage <= 0 {
+		pageOpt.PerPage = defaultTeamsPerPage
+	}
+
+	return newPageIterator(pageOpt.Page, pageOpt.PerPage, func(ctx context.Context, page int) ([]*grafana.Team, error) {
+		pageOpt.Page = page
+		result, err := s.Search(ctx, &pageOpt)
+		if err != nil {
+			return nil, err
+		}
+		return result.Teams, nil
+	})
+}
+
+// GetByID fetches team by given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#get-team-by-id
+func (s *TeamsService) GetByID(ctx context.Context, id grafana.TeamID) (*grafana.Team, error) {
+	u := fmt.Sprintf("/api/teams/%d", id)
+	var team grafana.Team
+	if err := s.do(ctx, "GET", u, nil, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+// teamRequest contains fields of team that can be set on create and update.
+type teamRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+// Create creates a new team in the current organization. ID of the created
+// team is set to team.ID.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#add-team
+func (s *TeamsService) Create(ctx context.Context, team *grafana.Team) error {
+	var result struct {
+		TeamID grafana.TeamID `json:"teamId"`
+	}
+	if err := s.do(ctx, "POST", "/api/teams", teamRequest{team.Name, team.Email}, &result); err != nil {
+		return err
+	}
+
+	team.ID = result.TeamID
+	return nil
+}
+
+// Update updates name and email of given team.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#update-team
+func (s *TeamsService) Update(ctx context.Context, team *grafana.Team) error {
+	u := fmt.Sprintf("/api/teams/%d", team.ID)
+	return s.do(ctx, "PUT", u, teamRequest{team.Name, team.Email}, nil)
+}
+
+// Delete deletes team with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#delete-team-by-id
+func (s *TeamsService) Delete(ctx context.Context, id grafana.TeamID) error {
+	u := fmt.Sprintf("/api/teams/%d", id)
+	return s.do(ctx, "DELETE", u, nil, nil)
+}
+
+// ListMembers fetches members of team with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#get-team-members
+func (s *TeamsService) ListMembers(ctx context.Context, id grafana.TeamID) ([]*grafana.TeamMember, error) {
+	u := fmt.Sprintf("/api/teams/%d/members", id)
+	var members []*grafana.TeamMember
+	if err := s.do(ctx, "GET", u, nil, &members); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddMember adds user with given id to team with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#add-team-member
+func (s *TeamsService) AddMember(ctx context.Context, id grafana.TeamID, userID grafana.UserID) error {
+	u := fmt.Sprintf("/api/teams/%d/members", id)
+	body := struct {
+		UserID grafana.UserID `json:"userId"`
+	}{userID}
+	return s.do(ctx, "POST", u, body, nil)
+}
+
+// RemoveMember removes user with given id from team with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#remove-member-from-team
+func (s *TeamsService) RemoveMember(ctx context.Context, id grafana.TeamID, userID grafana.UserID) error {
+	u := fmt.Sprintf("/api/teams/%d/members/%d", id, userID)
+	return s.do(ctx, "DELETE", u, nil, nil)
+}
+
+// Preferences fetches UI preferences of team with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#get-team-preferences
+func (s *TeamsService) Preferences(ctx context.Context, id grafana.TeamID) (*grafana.Preferences, error) {
+	u := fmt.Sprintf("/api/teams/%d/preferences", id)
+	var prefs grafana.Preferences
+	if err := s.do(ctx, "GET", u, nil, &prefs); err != nil {
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+// UpdatePreferences updates UI preferences of team with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#update-team-preferences
+func (s *TeamsService) UpdatePreferences(ctx context.Context, id grafana.TeamID, prefs *grafana.Preferences) error {
+	u := fmt.Sprintf("/api/teams/%d/preferences", id)
+	return s.do(ctx, "PUT", u, prefs, nil)
+}
+
+// PatchPreferences updates non-empty UI preferences of team with given id, the
+// other ones are left intact.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team/#patch-team-preferences
+func (s *TeamsService) PatchPreferences(ctx context.Context, id grafana.TeamID, prefs *grafana.Preferences) error {
+	u := fmt.Sprintf("/api/teams/%d/preferences", id)
+	return s.do(ctx, "PATCH", u, preferencesPatch(*prefs), nil)
+}
+
+// Groups fetches external groups synchronized with team with given id. Team
+// sync is available in Grafana Enterprise only.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team_sync/#get-external-groups
+func (s *TeamsService) Groups(ctx context.Context, id grafana.TeamID) ([]*grafana.TeamGroup, error) {
+	u := fmt.Sprintf("/api/teams/%d/groups", id)
+	var groups []*grafana.TeamGroup
+	if err := s.do(ctx, "GET", u, nil, &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// AddGroup adds external group with given id to team with given id, so that
+// members of the group become members of the team.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team_sync/#add-external-group
+func (s *TeamsService) AddGroup(ctx context.Context, id grafana.TeamID, groupID string) error {
+	u := fmt.Sprintf("/api/teams/%d/groups", id)
+	body := struct {
+		GroupID string `json:"groupId"`
+	}{groupID}
+	return s.do(ctx, "POST", u, body, nil)
+}
+
+// RemoveGroup removes external group with given id from team with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/team_sync/#remove-external-group
+func (s *TeamsService) RemoveGroup(ctx context.Context, id grafana.TeamID, groupID string) error {
+	// Group id is passed as a parameter since it can be an LDAP DN containing
+	// slashes.
+	opt := struct {
+		GroupID string `url:"groupId"`
+	}{groupID}
+	u, err := addOptions(fmt.Sprintf("/api/teams/%d/groups", id), opt)
+	if err != nil {
+		return err
+	}
+
+	return s.do(ctx, "DELETE", u, nil, nil)
+}
+
+// do sends an API request and decodes the response into v if it's not nil.
+// It returns ErrTeamNotFound if the API responds with 404 and ErrTeamNameTaken
+// if it responds with 409.
+func (s *TeamsService) do(ctx context.Context, method, u string, body, v interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, v); err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case http.StatusNotFound:
+				return ErrTeamNotFound
+			case http.StatusConflict:
+				return ErrTeamNameTaken
+			}
+		}
+		return err
+	}
+
+	return nil
+}
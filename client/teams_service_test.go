@@ -0,0 +1,201 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestTeamsService_SearchAll(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/teams/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			testFormValues(t, r, url.Values{"query": {"eng"}, "page": {"1"}, "perpage": {"2"}})
+			fmt.Fprint(w, `{"totalCount": 3, "teams": [{"id": 1, "orgId": 1, "name": "Engineering", "memberCount": 3}, {"id": 2, "orgId": 1, "name": "Engineering Ops"}], "page": 1, "perPage": 2}`)
+		case "2":
+			fmt.Fprint(w, `{"totalCount": 3, "teams": [{"id": 3, "orgId": 1, "name": "Engineering Support"}], "page": 2, "perPage": 2}`)
+		default:
+			t.Errorf("Unexpected page requested: %s", r.URL.RawQuery)
+		}
+	})
+
+	it := client.Teams.SearchAll(&TeamSearchOptions{Query: "eng", PerPage: 2})
+	var names []string
+	for it.Next(context.Background()) {
+		names = append(names, it.Value().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Teams.SearchAll returned error: %v", err)
+	}
+	if want := []string{"Engineering", "Engineering Ops", "Engineering Support"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Teams.SearchAll returned %v, want %v", names, want)
+	}
+}
+
+func TestTeamsService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	taken := false
+	mux.HandleFunc("/api/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if taken {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message": "Team name taken"}`)
+			return
+		}
+		testBody(t, r, `{"name":"Platform","email":"platform@example.com"}`+"\n")
+		fmt.Fprint(w, `{"teamId": 4, "message": "Team created"}`)
+	})
+
+	team := &grafana.Team{Name: "Platform", Email: "platform@example.com"}
+	if err := client.Teams.Create(context.Background(), team); err != nil {
+		t.Fatalf("Teams.Create returned error: %v", err)
+	}
+	if team.ID != 4 {
+		t.Errorf("Teams.Create set ID %d, want 4", team.ID)
+	}
+
+	taken = true
+	if err := client.Teams.Create(context.Background(), &grafana.Team{Name: "Platform"}); err != ErrTeamNameTaken {
+		t.Errorf("Teams.Create returned error %v, want %v", err, ErrTeamNameTaken)
+	}
+}
+
+func TestTeamsService_Members(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/teams/1/members", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"orgId": 1, "teamId": 1, "userId": 3, "email": "user@localhost", "login": "user", "labels": ["LDAP"], "permission": 4}]`)
+		case "POST":
+			testBody(t, r, `{"userId":5}`+"\n")
+			fmt.Fprint(w, `{"message": "Member added to Team"}`)
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/api/teams/1/members/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"message": "Team Member removed"}`)
+	})
+
+	ctx := context.Background()
+	members, err := client.Teams.ListMembers(ctx, 1)
+	if err != nil {
+		t.Fatalf("Teams.ListMembers returned error: %v", err)
+	}
+	want := []*grafana.TeamMember{{
+		OrgID: 1, TeamID: 1, UserID: 3, Email: "user@localhost", Login: "user",
+		Labels: []string{"LDAP"}, Permission: grafana.AdminTeamPermission,
+	}}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("Teams.ListMembers returned %+v, want %+v", members, want)
+	}
+
+	if err := client.Teams.AddMember(ctx, 1, 5); err != nil {
+		t.Errorf("Teams.AddMember returned error: %v", err)
+	}
+	if err := client.Teams.RemoveMember(ctx, 1, 5); err != nil {
+		t.Errorf("Teams.RemoveMember returned error: %v", err)
+	}
+	if _, err := client.Teams.ListMembers(ctx, 2); err != ErrTeamNotFound {
+		t.Errorf("Teams.ListMembers returned error %v, want %v", err, ErrTeamNotFound)
+	}
+}
+
+func TestTeamsService_RemoveGroup(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	group := "cn=editors,ou=groups,dc=grafana,dc=org"
+	mux.HandleFunc("/api/teams/1/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testFormValues(t, r, url.Values{"groupId": {group}})
+		fmt.Fprint(w, `{"message": "Team Group removed"}`)
+	})
+
+	if err := client.Teams.RemoveGroup(context.Background(), 1, group); err != nil {
+		t.Errorf("Teams.RemoveGroup returned error: %v", err)
+	}
+}
+
+func TestTeamsService_Preferences(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/teams/2/preferences", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"theme": "dark", "homeDashboardId": 5, "homeDashboardUID": "ops", "timezone": "utc", "weekStart": "monday", "locale": "en-GB"}`)
+		case "PUT":
+			testBody(t, r, `{"theme":"dark","homeDashboardId":0,"homeDashboardUID":"ops","timezone":"Europe/London","weekStart":"monday","locale":"en-GB"}`+"\n")
+		case "PATCH":
+			testBody(t, r, `{"timezone":"browser"}`+"\n")
+		default:
+			t.Errorf("Unexpected request method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	prefs, err := client.Teams.Preferences(ctx, 2)
+	if err != nil {
+		t.Fatalf("Teams.Preferences returned error: %v", err)
+	}
+	want := &grafana.Preferences{
+		Theme:            "dark",
+		HomeDashboardID:  5,
+		HomeDashboardUID: "ops",
+		Timezone:         "utc",
+		WeekStart:        "monday",
+		Locale:           "en-GB",
+	}
+	if !reflect.DeepEqual(prefs, want) {
+		t.Errorf("Teams.Preferences returned %+v, want %+v", prefs, want)
+	}
+
+	prefs.HomeDashboardID = 0
+	prefs.Timezone = "Europe/London"
+	if err := client.Teams.UpdatePreferences(ctx, 2, prefs); err != nil {
+		t.Errorf("Teams.UpdatePreferences returned error: %v", err)
+	}
+
+	if err := client.Teams.PatchPreferences(ctx, 2, &grafana.Preferences{Timezone: "browser"}); err != nil {
+		t.Errorf("Teams.PatchPreferences returned error: %v", err)
+	}
+}
@@ -0,0 +1,455 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+// UsersService communicates with user methods of the Grafana API.
+//
+// Methods working with a user given by id require basic authentication of a
+// Grafana server admin. Methods working with the current user require basic
+// authentication of that user.
+type UsersService struct {
+	client *Client
+}
+
+// NewUsersService returns a new UsersService.
+func NewUsersService(client *Client) *UsersService {
+	return &UsersService{
+		client: client,
+	}
+}
+
+// ErrUserNotFound represents an error if user not found.
+var ErrUserNotFound = errors.New("User not found")
+
+// UserSearchOptions specifies the optional parameters to the
+// UsersService.Search method.
+type UserSearchOptions struct {
+	Query   string `url:"query,omitempty"` // part of login, email or name
+	Page    int    `url:"page,omitempty"`
+	PerPage int    `url:"perpage,omitempty"`
+}
+
+// UserSearchResult is a page of users found by UsersService.Search.
+type UserSearchResult struct {
+	TotalCount int             `json:"totalCount"`
+	Users      []*grafana.User `json:"users"`
+	Page       int             `json:"page"`
+	PerPage    int             `json:"perPage"`
+}
+
+// Search searches users in all organizations with given criteria.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#search-users-with-paging
+func (s *UsersService) Search(ctx context.Context, opt *UserSearchOptions) (*UserSearchResult, error) {
+	u, err := addOptions("/api/users/search", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var result UserSearchResult
+	if err := s.do(ctx, "GET", u, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// defaultUsersPerPage is a number of users per page that Grafana uses if it
+// isn't given.
+const defaultUsersPerPage = 1000
+
+// SearchAll returns iterator over all users found with given criteria. Results
+// are fetched page by page starting from opt.Page.
+func (s *UsersService) SearchAll(opt *UserSearchOptions) *Iterator[*grafana.User] {
+	pageOpt := UserSearchOptions{}
+	if opt != nil {
+		pageOpt = *opt
+	}
+	if pageOpt.PerPage <= 0 {
+		pageOpt.PerPage = defaultUsersPerPage
+	}
+
+	return newPageIterator(pageOpt.Page, pageOpt.PerPage, func(ctx context.Context, page int) ([]*grafana.User, error) {
+		pageOpt.Page = page
+		result, err := s.Search(ctx, &pageOpt)
+		if err != nil {
+			return nil, err
+		}
+		return result.Users, nil
+	})
+}
+
+// GetByID fetches user by given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#get-single-user-by-id
+func (s *UsersService) GetByID(ctx context.Context, id grafana.UserID) (*grafana.User, error) {
+	return s.get(ctx, fmt.Sprintf("/api/users/%d", id))
+}
+
+// GetByLoginOrEmail fetches user with given login or email.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#get-single-user-by-usernamelogin-or-email
+func (s *UsersService) GetByLoginOrEmail(ctx context.Context, loginOrEmail string) (*grafana.User, error) {
+	if loginOrEmail == "" {
+		return nil, errors.New("Login or email cannot be empty")
+	}
+
+	opt := struct {
+		LoginOrEmail string `url:"loginOrEmail"`
+	}{loginOrEmail}
+	u, err := addOptions("/api/users/lookup", opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.get(ctx, u)
+}
+
+// Current fetches the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#actual-user
+func (s *UsersService) Current(ctx context.Context) (*grafana.User, error) {
+	return s.get(ctx, "/api/user")
+}
+
+func (s *UsersService) get(ctx context.Context, u string) (*grafana.User, error) {
+	var user grafana.User
+	if err := s.do(ctx, "GET", u, nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// userUpdateRequest contains fields of user that can be updated.
+type userUpdateRequest struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Login string `json:"login"`
+	Theme string `json:"theme"`
+}
+
+func newUserUpdateRequest(user *grafana.User) userUpdateRequest {
+	return userUpdateRequest{Email: user.Email, Name: user.Name, Login: user.Login, Theme: user.Theme}
+}
+
+// Update updates email, name, login and theme of given user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#user-update
+func (s *UsersService) Update(ctx context.Context, user *grafana.User) error {
+	u := fmt.Sprintf("/api/users/%d", user.ID)
+	return s.do(ctx, "PUT", u, newUserUpdateRequest(user), nil)
+}
+
+// UpdateCurrent updates email, name, login and theme of the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#update-current-user
+func (s *UsersService) UpdateCurrent(ctx context.Context, user *grafana.User) error {
+	return s.do(ctx, "PUT", "/api/user", newUserUpdateRequest(user), nil)
+}
+
+// Orgs fetches organizations that user with given id is a member of.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#get-organizations-for-user
+func (s *UsersService) Orgs(ctx context.Context, id grafana.UserID) ([]*grafana.UserOrg, error) {
+	return s.orgs(ctx, fmt.Sprintf("/api/users/%d/orgs", id))
+}
+
+// CurrentOrgs fetches organizations that the current user is a member of.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#organizations-of-the-actual-user
+func (s *UsersService) CurrentOrgs(ctx context.Context) ([]*grafana.UserOrg, error) {
+	return s.orgs(ctx, "/api/user/orgs")
+}
+
+func (s *UsersService) orgs(ctx context.Context, u string) ([]*grafana.UserOrg, error) {
+	var orgs []*grafana.UserOrg
+	if err := s.do(ctx, "GET", u, nil, &orgs); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// Teams fetches teams that user with given id is a member of.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#get-teams-for-user
+func (s *UsersService) Teams(ctx context.Context, id grafana.UserID) ([]*grafana.Team, error) {
+	return s.teams(ctx, fmt.Sprintf("/api/users/%d/teams", id))
+}
+
+// CurrentTeams fetches teams that the current user is a member of.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#teams-that-the-actual-user-is-member-of
+func (s *UsersService) CurrentTeams(ctx context.Context) ([]*grafana.Team, error) {
+	return s.teams(ctx, "/api/user/teams")
+}
+
+func (s *UsersService) teams(ctx context.Context, u string) ([]*grafana.Team, error) {
+	var teams []*grafana.Team
+	if err := s.do(ctx, "GET", u, nil, &teams); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// SwitchOrg switches the current organization of user with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#switch-user-context-for-a-specified-user
+func (s *UsersService) SwitchOrg(ctx context.Context, id grafana.UserID, orgID grafana.OrgID) error {
+	u := fmt.Sprintf("/api/users/%d/using/%d", id, orgID)
+	return s.do(ctx, "POST", u, nil, nil)
+}
+
+// SwitchCurrentOrg switches the current organization of the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#switch-user-context-for-signed-in-user
+func (s *UsersService) SwitchCurrentOrg(ctx context.Context, orgID grafana.OrgID) error {
+	u := fmt.Sprintf("/api/user/using/%d", orgID)
+	return s.do(ctx, "POST", u, nil, nil)
+}
+
+// ChangeCurrentPassword changes password of the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#change-password
+func (s *UsersService) ChangeCurrentPassword(ctx context.Context, oldPassword, newPassword string) error {
+	body := struct {
+		OldPassword string `json:"oldPassword"`
+		NewPassword string `json:"newPassword"`
+		ConfirmNew  string `json:"confirmNew"`
+	}{oldPassword, newPassword, newPassword}
+	return s.do(ctx, "PUT", "/api/user/password", body, nil)
+}
+
+// StarDashboard stars dashboard with given id for the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#star-a-dashboard
+func (s *UsersService) StarDashboard(ctx context.Context, dashboardID grafana.DashboardID) error {
+	u := fmt.Sprintf("/api/user/stars/dashboard/%d", dashboardID)
+	return s.star(ctx, "POST", u)
+}
+
+// StarDashboardByUID stars dashboard with given uid for the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#star-a-dashboard-by-uid
+func (s *UsersService) StarDashboardByUID(ctx context.Context, uid string) error {
+	return s.star(ctx, "POST", "/api/user/stars/dashboard/uid/"+url.PathEscape(uid))
+}
+
+// UnstarDashboard unstars dashboard with given id for the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#unstar-a-dashboard
+func (s *UsersService) UnstarDashboard(ctx context.Context, dashboardID grafana.DashboardID) error {
+	u := fmt.Sprintf("/api/user/stars/dashboard/%d", dashboardID)
+	return s.star(ctx, "DELETE", u)
+}
+
+// UnstarDashboardByUID unstars dashboard with given uid for the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#unstar-a-dashboard-by-uid
+func (s *UsersService) UnstarDashboardByUID(ctx context.Context, uid string) error {
+	return s.star(ctx, "DELETE", "/api/user/stars/dashboard/uid/"+url.PathEscape(uid))
+}
+
+// star sends a request starring or unstarring a dashboard. It returns
+// ErrDashboardNotFound if the API responds with 404.
+func (s *UsersService) star(ctx context.Context, method, u string) error {
+	req, err := s.client.NewRequest(ctx, method, u, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, nil); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrDashboardNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// StarredDashboards fetches uids of dashboards starred by the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/user/#get-user-stars
+func (s *UsersService) StarredDashboards(ctx context.Context) ([]string, error) {
+	var uids []string
+	if err := s.do(ctx, "GET", "/api/user/stars", nil, &uids); err != nil {
+		return nil, err
+	}
+
+	return uids, nil
+}
+
+// CurrentPreferences fetches UI preferences of the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/preferences/#get-current-user-prefs
+func (s *UsersService) CurrentPreferences(ctx context.Context) (*grafana.Preferences, error) {
+	var prefs grafana.Preferences
+	if err := s.do(ctx, "GET", "/api/user/preferences", nil, &prefs); err != nil {
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+// UpdateCurrentPreferences updates UI preferences of the current user.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/preferences/#update-current-user-prefs
+func (s *UsersService) UpdateCurrentPreferences(ctx context.Context, prefs *grafana.Preferences) error {
+	return s.do(ctx, "PUT", "/api/user/preferences", prefs, nil)
+}
+
+// PatchCurrentPreferences updates non-empty UI preferences of the current user,
+// the other ones are left intact.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/preferences/#patch-current-user-prefs
+func (s *UsersService) PatchCurrentPreferences(ctx context.Context, prefs *grafana.Preferences) error {
+	return s.do(ctx, "PATCH", "/api/user/preferences", preferencesPatch(*prefs), nil)
+}
+
+// Create creates a new user with given password. The user is added to
+// organization user.OrgID or to the default one if it's zero. ID of the
+// created user is set to user.ID.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#global-users
+func (s *UsersService) Create(ctx context.Context, user *grafana.User, password string) error {
+	body := struct {
+		Name     string        `json:"name"`
+		Email    string        `json:"email"`
+		Login    string        `json:"login"`
+		Password string        `json:"password"`
+		OrgID    grafana.OrgID `json:"OrgId,omitempty"`
+	}{user.Name, user.Email, user.Login, password, user.OrgID}
+
+	var result struct {
+		ID grafana.UserID `json:"id"`
+	}
+	if err := s.do(ctx, "POST", "/api/admin/users", body, &result); err != nil {
+		return err
+	}
+
+	user.ID = result.ID
+	return nil
+}
+
+// SetPassword sets password of user with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#password-for-user
+func (s *UsersService) SetPassword(ctx context.Context, id grafana.UserID, password string) error {
+	u := fmt.Sprintf("/api/admin/users/%d/password", id)
+	body := struct {
+		Password string `json:"password"`
+	}{password}
+	return s.do(ctx, "PUT", u, body, nil)
+}
+
+// SetPermissions grants or revokes Grafana server admin permissions of user
+// with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#permissions
+func (s *UsersService) SetPermissions(ctx context.Context, id grafana.UserID, isGrafanaAdmin bool) error {
+	u := fmt.Sprintf("/api/admin/users/%d/permissions", id)
+	body := struct {
+		IsGrafanaAdmin bool `json:"isGrafanaAdmin"`
+	}{isGrafanaAdmin}
+	return s.do(ctx, "PUT", u, body, nil)
+}
+
+// Disable disables user with given id, so that they can't log in.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#disable-user
+func (s *UsersService) Disable(ctx context.Context, id grafana.UserID) error {
+	u := fmt.Sprintf("/api/admin/users/%d/disable", id)
+	return s.do(ctx, "POST", u, nil, nil)
+}
+
+// Enable enables user with given id disabled before.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#enable-user
+func (s *UsersService) Enable(ctx context.Context, id grafana.UserID) error {
+	u := fmt.Sprintf("/api/admin/users/%d/enable", id)
+	return s.do(ctx, "POST", u, nil, nil)
+}
+
+// Delete deletes user with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#delete-global-user
+func (s *UsersService) Delete(ctx context.Context, id grafana.UserID) error {
+	u := fmt.Sprintf("/api/admin/users/%d", id)
+	return s.do(ctx, "DELETE", u, nil, nil)
+}
+
+// Logout logs out user with given id from all devices by revoking all of their
+// auth tokens.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#logout-user
+func (s *UsersService) Logout(ctx context.Context, id grafana.UserID) error {
+	u := fmt.Sprintf("/api/admin/users/%d/logout", id)
+	return s.do(ctx, "POST", u, nil, nil)
+}
+
+// AuthTokens fetches auth tokens of login sessions of user with given id.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#auth-tokens-for-user
+func (s *UsersService) AuthTokens(ctx context.Context, id grafana.UserID) ([]*grafana.UserAuthToken, error) {
+	u := fmt.Sprintf("/api/admin/users/%d/auth-tokens", id)
+	var tokens []*grafana.UserAuthToken
+	if err := s.do(ctx, "GET", u, nil, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RevokeAuthToken revokes auth token with given id of user with given id, so
+// that the login session is logged out.
+//
+// Grafana API docs: http://docs.grafana.org/http_api/admin/#revoke-auth-token-for-user
+func (s *UsersService) RevokeAuthToken(ctx context.Context, id grafana.UserID, tokenID int64) error {
+	u := fmt.Sprintf("/api/admin/users/%d/revoke-auth-token", id)
+	body := struct {
+		AuthTokenID int64 `json:"authTokenId"`
+	}{tokenID}
+	return s.do(ctx, "POST", u, body, nil)
+}
+
+// do sends an API request and decodes the response into v if it's not nil.
+// It returns ErrUserNotFound if the API responds with 404.
+func (s *UsersService) do(ctx context.Context, method, u string, body, v interface{}) error {
+	req, err := s.client.NewRequest(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	if resp, err := s.client.Do(req, v); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
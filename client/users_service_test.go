@@ -0,0 +1,236 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana"
+)
+
+func TestUsersService_SearchAll(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/users/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch page := r.URL.Query().Get("page"); page {
+		case "1":
+			testFormValues(t, r, url.Values{"query": {"admin"}, "page": {"1"}, "perpage": {"1"}})
+			fmt.Fprint(w, `{"totalCount": 2, "users": [{"id": 1, "login": "admin", "isGrafanaAdmin": true, "lastSeenAt": "2020-01-02T15:04:05Z"}], "page": 1, "perPage": 1}`)
+		case "2":
+			fmt.Fprint(w, `{"totalCount": 2, "users": [{"id": 2, "login": "admin2", "isDisabled": true}], "page": 2, "perPage": 1}`)
+		default:
+			fmt.Fprintf(w, `{"totalCount": 2, "users": [], "page": %s, "perPage": 1}`, page)
+		}
+	})
+
+	it := client.Users.SearchAll(&UserSearchOptions{Query: "admin", PerPage: 1})
+	var users []*grafana.User
+	for it.Next(context.Background()) {
+		users = append(users, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Users.SearchAll returned error: %v", err)
+	}
+
+	want := []*grafana.User{
+		{ID: 1, Login: "admin", IsGrafanaAdmin: true, LastSeenAt: time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{ID: 2, Login: "admin2", IsDisabled: true},
+	}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Users.SearchAll returned %+v, want %+v", users, want)
+	}
+}
+
+func TestUsersService_GetByLoginOrEmail(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/users/lookup", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("loginOrEmail") != "user@localhost" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "User not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 2, "email": "user@localhost", "login": "user", "orgId": 1, "authLabels": ["OAuth"], "isExternal": true}`)
+	})
+
+	user, err := client.Users.GetByLoginOrEmail(context.Background(), "user@localhost")
+	if err != nil {
+		t.Fatalf("Users.GetByLoginOrEmail returned error: %v", err)
+	}
+	want := &grafana.User{ID: 2, Email: "user@localhost", Login: "user", OrgID: 1, AuthLabels: []string{"OAuth"}, IsExternal: true}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("Users.GetByLoginOrEmail returned %+v, want %+v", user, want)
+	}
+
+	if _, err := client.Users.GetByLoginOrEmail(context.Background(), "unknown"); err != ErrUserNotFound {
+		t.Errorf("Users.GetByLoginOrEmail returned error %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+func TestUsersService_Update(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/users/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"email":"user@localhost","name":"User","login":"user","theme":"light"}`+"\n")
+		fmt.Fprint(w, `{"message": "User updated"}`)
+	})
+
+	user := &grafana.User{ID: 2, Email: "user@localhost", Name: "User", Login: "user", Theme: "light", IsGrafanaAdmin: true}
+	if err := client.Users.Update(context.Background(), user); err != nil {
+		t.Errorf("Users.Update returned error: %v", err)
+	}
+}
+
+func TestUsersService_Create(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	mux.HandleFunc("/api/admin/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"User","email":"user@localhost","login":"user","password":"secret","OrgId":2}`+"\n")
+		fmt.Fprint(w, `{"id": 5, "message": "User created"}`)
+	})
+
+	user := &grafana.User{Name: "User", Email: "user@localhost", Login: "user", OrgID: 2}
+	if err := client.Users.Create(context.Background(), user, "secret"); err != nil {
+		t.Fatalf("Users.Create returned error: %v", err)
+	}
+	if user.ID != 5 {
+		t.Errorf("Users.Create set ID %d, want 5", user.ID)
+	}
+}
+
+func TestUsersService_Offboard(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "", nil)
+
+	var requests []string
+	handle := func(path, method, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, method)
+			if body != "" {
+				testBody(t, r, body+"\n")
+			}
+			requests = append(requests, path)
+			fmt.Fprint(w, `{"message": "ok"}`)
+		})
+	}
+	handle("/api/admin/users/5/permissions", "PUT", `{"isGrafanaAdmin":false}`)
+	handle("/api/admin/users/5/disable", "POST", "")
+	handle("/api/admin/users/5/revoke-auth-token", "POST", `{"authTokenId":3}`)
+	handle("/api/admin/users/5/logout", "POST", "")
+	handle("/api/admin/users/5", "DELETE", "")
+
+	ctx := context.Background()
+	if err := client.Users.SetPermissions(ctx, 5, false); err != nil {
+		t.Errorf("Users.SetPermissions returned error: %v", err)
+	}
+	if err := client.Users.Disable(ctx, 5); err != nil {
+		t.Errorf("Users.Disable returned error: %v", err)
+	}
+	if err := client.Users.RevokeAuthToken(ctx, 5, 3); err != nil {
+		t.Errorf("Users.RevokeAuthToken returned error: %v", err)
+	}
+	if err := client.Users.Logout(ctx, 5); err != nil {
+		t.Errorf("Users.Logout returned error: %v", err)
+	}
+	if err := client.Users.Delete(ctx, 5); err != nil {
+		t.Errorf("Users.Delete returned error: %v", err)
+	}
+	if err := client.Users.Disable(ctx, 6); err != ErrUserNotFound {
+		t.Errorf("Users.Disable returned error %v, want %v", err, ErrUserNotFound)
+	}
+
+	if len(requests) != 5 {
+		t.Errorf("Users: got %d requests, want 5", len(requests))
+	}
+}
+
+func TestUsersService_Stars(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/user/stars/dashboard/uid/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if r.URL.Path != "/api/user/stars/dashboard/uid/ops" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Dashboard not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"message": "Dashboard starred!"}`)
+	})
+	mux.HandleFunc("/api/user/stars", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `["ops", "home"]`)
+	})
+
+	ctx := context.Background()
+	if err := client.Users.StarDashboardByUID(ctx, "ops"); err != nil {
+		t.Errorf("Users.StarDashboardByUID returned error: %v", err)
+	}
+	if err := client.Users.StarDashboardByUID(ctx, "unknown"); err != ErrDashboardNotFound {
+		t.Errorf("Users.StarDashboardByUID returned error %v, want %v", err, ErrDashboardNotFound)
+	}
+
+	uids, err := client.Users.StarredDashboards(ctx)
+	if err != nil {
+		t.Fatalf("Users.StarredDashboards returned error: %v", err)
+	}
+	if want := []string{"ops", "home"}; !reflect.DeepEqual(uids, want) {
+		t.Errorf("Users.StarredDashboards returned %v, want %v", uids, want)
+	}
+}
+
+func TestUsersService_PatchCurrentPreferences(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewClient(baseURL, "token", nil)
+
+	mux.HandleFunc("/api/user/preferences", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"theme":"light","homeDashboardUID":"ops","weekStart":"monday"}`+"\n")
+		fmt.Fprint(w, `{"message": "Preferences updated"}`)
+	})
+
+	prefs := &grafana.Preferences{Theme: "light", HomeDashboardUID: "ops", WeekStart: "monday"}
+	if err := client.Users.PatchCurrentPreferences(context.Background(), prefs); err != nil {
+		t.Errorf("Users.PatchCurrentPreferences returned error: %v", err)
+	}
+}
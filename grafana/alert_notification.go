@@ -0,0 +1,184 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import "encoding/json"
+
+// AlertNotificationID is an ID type of AlertNotification
+type AlertNotificationID uint
+
+// AlertNotification is a notification channel of legacy alerting that alerts
+// of graph panels are sent to. Channels share types with contact points of
+// unified alerting, but their settings differ, e.g. *SlackNotificationSettings.
+type AlertNotification struct {
+	ID                    AlertNotificationID `json:"id,omitempty"`
+	UID                   string              `json:"uid,omitempty"`
+	Name                  string              `json:"name"`
+	Type                  contactPointType    `json:"type"`
+	IsDefault             bool                `json:"isDefault"` // used by all alerts
+	SendReminder          bool                `json:"sendReminder"`
+	Frequency             string              `json:"frequency,omitempty"` // interval of reminders, e.g. 15m
+	DisableResolveMessage bool                `json:"disableResolveMessage"`
+
+	// Settings are settings of integration of Type, e.g. *SlackNotificationSettings,
+	// or json.RawMessage for types without typed settings.
+	Settings interface{} `json:"settings"`
+
+	// SecureFields are names of settings that are stored encrypted and are
+	// not returned by the API.
+	SecureFields map[string]bool `json:"secureFields,omitempty"`
+
+	// settings keeps fields of unmarshaled typed settings that they don't have
+	settings rawObject
+}
+
+// AlertNotificationSettings are typed settings of a notification channel.
+type AlertNotificationSettings interface {
+	NotificationType() contactPointType
+}
+
+// NewAlertNotification creates a new AlertNotification with given name and
+// settings of integration.
+func NewAlertNotification(name string, settings AlertNotificationSettings) *AlertNotification {
+	return &AlertNotification{
+		Name:     name,
+		Type:     settings.NotificationType(),
+		Settings: settings,
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (n *AlertNotification) MarshalJSON() ([]byte, error) {
+	type JSONAlertNotification AlertNotification
+	jn := JSONAlertNotification(*n)
+	if typed, ok := n.Settings.(AlertNotificationSettings); ok {
+		settings, err := n.settings.encode(typed)
+		if err != nil {
+			return nil, err
+		}
+		jn.Settings = json.RawMessage(settings)
+	}
+	return json.Marshal(jn)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (n *AlertNotification) UnmarshalJSON(data []byte) error {
+	type JSONAlertNotification AlertNotification
+	var settings json.RawMessage
+	jn := JSONAlertNotification{Settings: &settings}
+	if err := json.Unmarshal(data, &jn); err != nil {
+		return err
+	}
+
+	*n = AlertNotification(jn)
+	n.Settings = json.RawMessage(settings)
+
+	var typed AlertNotificationSettings
+	switch n.Type {
+	case EmailContactPoint:
+		typed = new(EmailNotificationSettings)
+	case SlackContactPoint:
+		typed = new(SlackNotificationSettings)
+	case PagerDutyContactPoint:
+		typed = new(PagerDutyNotificationSettings)
+	case WebhookContactPoint:
+		typed = new(WebhookNotificationSettings)
+	case OpsgenieContactPoint:
+		typed = new(OpsgenieNotificationSettings)
+	default:
+		return nil
+	}
+	if len(settings) > 0 {
+		if err := n.settings.decode(settings, typed); err != nil {
+			return err
+		}
+	}
+	n.Settings = typed
+	return nil
+}
+
+// EmailNotificationSettings are settings of email notification channel.
+type EmailNotificationSettings struct {
+	Addresses   EmailAddresses `json:"addresses"`
+	SingleEmail bool           `json:"singleEmail"` // send a single email to all addresses
+	UploadImage bool           `json:"uploadImage,omitempty"`
+}
+
+// NotificationType implements AlertNotificationSettings interface
+func (s *EmailNotificationSettings) NotificationType() contactPointType {
+	return EmailContactPoint
+}
+
+// SlackNotificationSettings are settings of Slack notification channel. Either
+// URL of incoming webhook or Token of Slack app with Recipient should be set.
+type SlackNotificationSettings struct {
+	URL            string `json:"url,omitempty"`
+	Token          string `json:"token,omitempty"`
+	Recipient      string `json:"recipient,omitempty"` // channel or user
+	Username       string `json:"username,omitempty"`
+	IconEmoji      string `json:"iconEmoji,omitempty"`
+	IconURL        string `json:"iconUrl,omitempty"`
+	MentionChannel string `json:"mentionChannel,omitempty"` // here or channel
+	MentionUsers   string `json:"mentionUsers,omitempty"`
+	MentionGroups  string `json:"mentionGroups,omitempty"`
+	UploadImage    bool   `json:"uploadImage,omitempty"`
+}
+
+// NotificationType implements AlertNotificationSettings interface
+func (s *SlackNotificationSettings) NotificationType() contactPointType {
+	return SlackContactPoint
+}
+
+// PagerDutyNotificationSettings are settings of PagerDuty notification channel.
+type PagerDutyNotificationSettings struct {
+	IntegrationKey   string `json:"integrationKey"`
+	Severity         string `json:"severity,omitempty"` // critical, error, warning or info
+	AutoResolve      bool   `json:"autoResolve"`        // resolve incidents when alerts are OK
+	MessageInDetails bool   `json:"messageInDetails,omitempty"`
+	UploadImage      bool   `json:"uploadImage,omitempty"`
+}
+
+// NotificationType implements AlertNotificationSettings interface
+func (s *PagerDutyNotificationSettings) NotificationType() contactPointType {
+	return PagerDutyContactPoint
+}
+
+// WebhookNotificationSettings are settings of webhook notification channel.
+type WebhookNotificationSettings struct {
+	URL         string `json:"url"`
+	HTTPMethod  string `json:"httpMethod,omitempty"` // POST or PUT
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	UploadImage bool   `json:"uploadImage,omitempty"`
+}
+
+// NotificationType implements AlertNotificationSettings interface
+func (s *WebhookNotificationSettings) NotificationType() contactPointType {
+	return WebhookContactPoint
+}
+
+// OpsgenieNotificationSettings are settings of Opsgenie notification channel.
+type OpsgenieNotificationSettings struct {
+	APIKey           string `json:"apiKey"`
+	APIURL           string `json:"apiUrl,omitempty"`
+	AutoClose        bool   `json:"autoClose"`
+	OverridePriority bool   `json:"overridePriority"`
+	SendTagsAs       string `json:"sendTagsAs,omitempty"` // tags, details or both
+	UploadImage      bool   `json:"uploadImage,omitempty"`
+}
+
+// NotificationType implements AlertNotificationSettings interface
+func (s *OpsgenieNotificationSettings) NotificationType() contactPointType {
+	return OpsgenieContactPoint
+}
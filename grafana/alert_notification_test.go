@@ -0,0 +1,63 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func TestAlertNotification_JSON(t *testing.T) {
+	data := []byte(`{"id": 2, "uid": "pd", "name": "oncall", "type": "pagerduty",
+		"isDefault": true, "sendReminder": true, "frequency": "15m", "disableResolveMessage": false,
+		"settings": {"integrationKey": "", "severity": "critical", "autoResolve": true, "messageInDetails": true, "uploadImage": true, "customDetails": "keep"},
+		"secureFields": {"integrationKey": true}}`)
+
+	var got AlertNotification
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("AlertNotification.UnmarshalJSON returned error %s", err)
+	}
+
+	expected := NewAlertNotification("oncall", &PagerDutyNotificationSettings{
+		Severity:         "critical",
+		AutoResolve:      true,
+		MessageInDetails: true,
+		UploadImage:      true,
+	})
+	expected.ID = 2
+	expected.UID = "pd"
+	expected.IsDefault = true
+	expected.SendReminder = true
+	expected.Frequency = "15m"
+	expected.SecureFields = map[string]bool{"integrationKey": true}
+	// Settings without fields, ie. customDetails, are checked by marshaling below
+	stripped := got
+	stripped.settings = rawObject{}
+	if !reflect.DeepEqual(expected, &stripped) {
+		t.Errorf("AlertNotification.UnmarshalJSON: %s", pretty.Diff(expected, &stripped))
+	}
+
+	b, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatalf("AlertNotification.MarshalJSON returned error %s", err)
+	}
+	if eq, err := JSONBytesEqual(data, b); err != nil {
+		t.Fatalf("AlertNotification.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("AlertNotification.MarshalJSON:\ngot %s\nwant %s", b, data)
+	}
+}
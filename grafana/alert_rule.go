@@ -0,0 +1,227 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type alertRuleNoDataState string
+
+// States that alert rule is set to if its queries return no data.
+const (
+	NoDataAlertRuleState   alertRuleNoDataState = "NoData"
+	AlertingAlertRuleState alertRuleNoDataState = "Alerting"
+	OKAlertRuleState       alertRuleNoDataState = "OK"
+)
+
+type alertRuleErrorState string
+
+// States that alert rule is set to if its evaluation fails.
+const (
+	ErrorAlertRuleErrorState    alertRuleErrorState = "Error"
+	AlertingAlertRuleErrorState alertRuleErrorState = "Alerting"
+	OKAlertRuleErrorState       alertRuleErrorState = "OK"
+)
+
+// AlertRule is an alert rule of Grafana unified alerting. Rule evaluates its
+// queries and expressions and fires when the one referred by Condition is
+// non-zero for the For period.
+type AlertRule struct {
+	ID           int64                `json:"id,omitempty"`
+	UID          string               `json:"uid,omitempty"`
+	OrgID        OrgID                `json:"orgID"`
+	FolderUID    string               `json:"folderUID"`
+	RuleGroup    string               `json:"ruleGroup"`
+	Title        string               `json:"title"`
+	Condition    string               `json:"condition"` // refId of query or expression
+	Data         []*AlertRuleQuery    `json:"data"`
+	NoDataState  alertRuleNoDataState `json:"noDataState"`
+	ExecErrState alertRuleErrorState  `json:"execErrState"`
+	For          string               `json:"for"` // e.g. 5m
+	Annotations  map[string]string    `json:"annotations"`
+	Labels       map[string]string    `json:"labels"`
+	IsPaused     bool                 `json:"isPaused"`
+	Updated      *time.Time           `json:"updated,omitempty"`
+	Provenance   string               `json:"provenance,omitempty"` // e.g. api if rule can't be edited in UI
+}
+
+// NewAlertRule creates a new AlertRule in given folder and rule group with
+// defaults of Grafana UI.
+func NewAlertRule(title, folderUID, ruleGroup string) *AlertRule {
+	return &AlertRule{
+		Title:        title,
+		FolderUID:    folderUID,
+		RuleGroup:    ruleGroup,
+		Data:         []*AlertRuleQuery{},
+		NoDataState:  NoDataAlertRuleState,
+		ExecErrState: ErrorAlertRuleErrorState,
+		For:          "1m",
+		Annotations:  map[string]string{},
+		Labels:       map[string]string{},
+	}
+}
+
+// AlertRuleGroup is a group of alert rules of a folder evaluated with the same
+// interval.
+type AlertRuleGroup struct {
+	Title     string        `json:"title"`
+	FolderUID string        `json:"folderUid"`
+	Interval  time.Duration `json:"-"`
+	Rules     []*AlertRule  `json:"rules"`
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (g *AlertRuleGroup) MarshalJSON() ([]byte, error) {
+	type JSONAlertRuleGroup AlertRuleGroup
+	jg := struct {
+		*JSONAlertRuleGroup
+		Interval int64 `json:"interval"`
+	}{
+		JSONAlertRuleGroup: (*JSONAlertRuleGroup)(g),
+		Interval:           int64(g.Interval / time.Second),
+	}
+	return json.Marshal(jg)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (g *AlertRuleGroup) UnmarshalJSON(data []byte) error {
+	type JSONAlertRuleGroup AlertRuleGroup
+	jg := struct {
+		*JSONAlertRuleGroup
+		Interval int64 `json:"interval"`
+	}{
+		JSONAlertRuleGroup: (*JSONAlertRuleGroup)(g),
+	}
+	if err := json.Unmarshal(data, &jg); err != nil {
+		return err
+	}
+
+	g.Interval = time.Duration(jg.Interval) * time.Second
+	return nil
+}
+
+// ExpressionDatasourceUID is UID of the pseudo datasource evaluating
+// expressions of alert rules.
+const ExpressionDatasourceUID = "__expr__"
+
+// AlertRuleQuery is a query or an expression of alert rule.
+type AlertRuleQuery struct {
+	RefID             string            `json:"refId"`
+	QueryType         string            `json:"queryType"`
+	RelativeTimeRange RelativeTimeRange `json:"relativeTimeRange"`
+	DatasourceUID     string            `json:"datasourceUid"`
+
+	// Model is Expression if DatasourceUID is ExpressionDatasourceUID.
+	// Otherwise it's a query of datasource, e.g. *query.Prometheus. Queries of
+	// datasources and expressions of unknown types are unmarshaled as
+	// json.RawMessage.
+	Model interface{} `json:"model"`
+
+	// model keeps fields of unmarshaled expression that it doesn't have,
+	// ie. refId, datasource, intervalMs and maxDataPoints.
+	model rawObject
+}
+
+// NewAlertRuleQuery creates a new AlertRuleQuery sending model to datasource
+// with given uid for time range from given duration ago to now.
+func NewAlertRuleQuery(refID, datasourceUID string, from time.Duration, model interface{}) *AlertRuleQuery {
+	return &AlertRuleQuery{
+		RefID:             refID,
+		RelativeTimeRange: RelativeTimeRange{From: from},
+		DatasourceUID:     datasourceUID,
+		Model:             model,
+	}
+}
+
+// NewExpressionQuery creates a new AlertRuleQuery evaluating given expression.
+func NewExpressionQuery(refID string, e Expression) *AlertRuleQuery {
+	return &AlertRuleQuery{
+		RefID:         refID,
+		DatasourceUID: ExpressionDatasourceUID,
+		Model:         e,
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (q *AlertRuleQuery) MarshalJSON() ([]byte, error) {
+	type JSONAlertRuleQuery AlertRuleQuery
+	jq := JSONAlertRuleQuery(*q)
+	if e, ok := q.Model.(Expression); ok {
+		model, err := marshalExpression(e, &q.model)
+		if err != nil {
+			return nil, err
+		}
+		jq.Model = model
+	}
+	return json.Marshal(jq)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (q *AlertRuleQuery) UnmarshalJSON(data []byte) error {
+	type JSONAlertRuleQuery AlertRuleQuery
+	var model json.RawMessage
+	jq := JSONAlertRuleQuery{Model: &model}
+	if err := json.Unmarshal(data, &jq); err != nil {
+		return err
+	}
+
+	*q = AlertRuleQuery(jq)
+	q.Model = model
+	if q.DatasourceUID != ExpressionDatasourceUID {
+		return nil
+	}
+
+	e, err := unmarshalExpression(model, &q.model)
+	if err != nil {
+		return fmt.Errorf("query %s: %s", q.RefID, err)
+	}
+	if e != nil {
+		q.Model = e
+	}
+	return nil
+}
+
+// RelativeTimeRange is a time range of alert rule's query relative to the
+// time of evaluation, e.g. from 10m to 0 (now).
+type RelativeTimeRange struct {
+	From time.Duration
+	To   time.Duration
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (r RelativeTimeRange) MarshalJSON() ([]byte, error) {
+	jr := struct {
+		From int64 `json:"from"`
+		To   int64 `json:"to"`
+	}{int64(r.From / time.Second), int64(r.To / time.Second)}
+	return json.Marshal(jr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (r *RelativeTimeRange) UnmarshalJSON(data []byte) error {
+	var jr struct {
+		From int64 `json:"from"`
+		To   int64 `json:"to"`
+	}
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return err
+	}
+
+	r.From = time.Duration(jr.From) * time.Second
+	r.To = time.Duration(jr.To) * time.Second
+	return nil
+}
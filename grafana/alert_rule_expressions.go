@@ -0,0 +1,219 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import "encoding/json"
+
+type expressionType string
+
+// Types of expressions of alert rules.
+const (
+	ReduceExpressionType            expressionType = "reduce"
+	MathExpressionType              expressionType = "math"
+	ThresholdExpressionType         expressionType = "threshold"
+	ClassicConditionsExpressionType expressionType = "classic_conditions"
+)
+
+// Expression is an expression of alert rule processing results of its queries
+// or other expressions.
+type Expression interface {
+	ExpressionType() expressionType
+}
+
+// marshalExpression marshals e adding its type. Fields of model that e doesn't
+// have are kept.
+func marshalExpression(e Expression, model *rawObject) (json.RawMessage, error) {
+	data, err := model.encode(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["type"], _ = json.Marshal(e.ExpressionType())
+	return json.Marshal(fields)
+}
+
+// unmarshalExpression unmarshals expression of the type it has, keeping its
+// JSON in model. It returns nil for expressions of unknown types.
+func unmarshalExpression(data []byte, model *rawObject) (Expression, error) {
+	var probe struct {
+		Type expressionType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	var e Expression
+	switch probe.Type {
+	case ReduceExpressionType:
+		e = new(ReduceExpression)
+	case MathExpressionType:
+		e = new(MathExpression)
+	case ThresholdExpressionType:
+		e = new(ThresholdExpression)
+	case ClassicConditionsExpressionType:
+		e = new(ClassicConditionsExpression)
+	default:
+		return nil, nil
+	}
+
+	if err := model.decode(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+type expressionReducer string
+
+// Functions reducing series to a single value.
+const (
+	LastExpressionReducer  expressionReducer = "last"
+	MeanExpressionReducer  expressionReducer = "mean"
+	MinExpressionReducer   expressionReducer = "min"
+	MaxExpressionReducer   expressionReducer = "max"
+	SumExpressionReducer   expressionReducer = "sum"
+	CountExpressionReducer expressionReducer = "count"
+)
+
+type reduceMode string
+
+// Modes of handling non-numeric values by reduce expression.
+const (
+	StrictReduceMode      reduceMode = ""
+	DropNonNumbersMode    reduceMode = "dropNN"
+	ReplaceNonNumbersMode reduceMode = "replaceNN"
+)
+
+// ReduceExpression reduces each series of query to a single value.
+type ReduceExpression struct {
+	Expression string            `json:"expression"` // refId of the input
+	Reducer    expressionReducer `json:"reducer"`
+	Settings   *ReduceSettings   `json:"settings,omitempty"`
+}
+
+// ReduceSettings set how reduce expression handles non-numeric values.
+type ReduceSettings struct {
+	Mode             reduceMode `json:"mode"`
+	ReplaceWithValue *float64   `json:"replaceWithValue,omitempty"` // for ReplaceNonNumbersMode
+}
+
+// ExpressionType implements Expression interface
+func (e *ReduceExpression) ExpressionType() expressionType {
+	return ReduceExpressionType
+}
+
+// MathExpression evaluates math on results of other queries and expressions,
+// e.g. $A / $B > 0.05.
+type MathExpression struct {
+	Expression string `json:"expression"`
+}
+
+// ExpressionType implements Expression interface
+func (e *MathExpression) ExpressionType() expressionType {
+	return MathExpressionType
+}
+
+// ThresholdExpression checks values of its input with evaluator. It returns 1
+// for values matching the condition, 0 otherwise.
+type ThresholdExpression struct {
+	Expression string               `json:"expression"` // refId of the input
+	Conditions []ThresholdCondition `json:"conditions"`
+}
+
+// NewThresholdExpression creates a new ThresholdExpression checking input with
+// given refId with given evaluator.
+func NewThresholdExpression(input string, evaluator ExpressionEvaluator) *ThresholdExpression {
+	return &ThresholdExpression{
+		Expression: input,
+		Conditions: []ThresholdCondition{{Evaluator: evaluator}},
+	}
+}
+
+// ThresholdCondition is a condition of threshold expression.
+type ThresholdCondition struct {
+	Evaluator ExpressionEvaluator `json:"evaluator"`
+}
+
+// ExpressionType implements Expression interface
+func (e *ThresholdExpression) ExpressionType() expressionType {
+	return ThresholdExpressionType
+}
+
+type evaluatorType string
+
+// Types of evaluators of threshold and classic conditions.
+const (
+	GreaterEvaluator      evaluatorType = "gt"
+	LessEvaluator         evaluatorType = "lt"
+	WithinRangeEvaluator  evaluatorType = "within_range"
+	OutsideRangeEvaluator evaluatorType = "outside_range"
+)
+
+// ExpressionEvaluator checks a value against params.
+type ExpressionEvaluator struct {
+	Type   evaluatorType `json:"type"`
+	Params []float64     `json:"params"`
+}
+
+// ClassicConditionsExpression combines conditions the same way legacy alerts
+// do. It returns 1 if conditions are met, 0 otherwise.
+type ClassicConditionsExpression struct {
+	Conditions []ClassicCondition `json:"conditions"`
+}
+
+// ExpressionType implements Expression interface
+func (e *ClassicConditionsExpression) ExpressionType() expressionType {
+	return ClassicConditionsExpressionType
+}
+
+// ClassicCondition is a condition of classic conditions expression that checks
+// a reduced value of a query.
+type ClassicCondition struct {
+	Type      string                   `json:"type"` // always query
+	Query     ClassicConditionQuery    `json:"query"`
+	Reducer   ClassicConditionReducer  `json:"reducer"`
+	Evaluator ExpressionEvaluator      `json:"evaluator"`
+	Operator  ClassicConditionOperator `json:"operator"`
+}
+
+// NewClassicCondition creates a new ClassicCondition reducing query with given
+// refId with given reducer, e.g. avg, and checking the result with evaluator.
+// The condition is combined with the previous ones with "and" operator.
+func NewClassicCondition(refID, reducer string, evaluator ExpressionEvaluator) ClassicCondition {
+	return ClassicCondition{
+		Type:      "query",
+		Query:     ClassicConditionQuery{Params: []string{refID}},
+		Reducer:   ClassicConditionReducer{Type: reducer},
+		Evaluator: evaluator,
+		Operator:  ClassicConditionOperator{Type: "and"},
+	}
+}
+
+// ClassicConditionQuery refers to a query by refId, the only param.
+type ClassicConditionQuery struct {
+	Params []string `json:"params"`
+}
+
+// ClassicConditionReducer reduces query series to a single value.
+type ClassicConditionReducer struct {
+	Type string `json:"type"` // avg, min, max, sum, count, last, median, diff, etc.
+}
+
+// ClassicConditionOperator combines condition with the previous ones.
+type ClassicConditionOperator struct {
+	Type string `json:"type"` // and/or
+}
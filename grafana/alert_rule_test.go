@@ -0,0 +1,198 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kr/pretty"
+)
+
+func TestAlertRule_JSON(t *testing.T) {
+	data := []byte(`{
+		"uid": "slo-latency",
+		"orgID": 1,
+		"folderUID": "slo",
+		"ruleGroup": "latency",
+		"title": "Latency SLO",
+		"condition": "C",
+		"data": [
+			{
+				"refId": "A",
+				"queryType": "",
+				"relativeTimeRange": {"from": 600, "to": 0},
+				"datasourceUid": "prometheus",
+				"model": {"expr": "histogram_quantile(0.99, rate(latency_bucket[5m]))", "refId": "A"}
+			},
+			{
+				"refId": "B",
+				"queryType": "",
+				"relativeTimeRange": {"from": 0, "to": 0},
+				"datasourceUid": "__expr__",
+				"model": {
+					"type": "reduce", "expression": "A", "reducer": "mean", "settings": {"mode": "dropNN"},
+					"refId": "B", "datasource": {"type": "__expr__", "uid": "__expr__"}, "intervalMs": 1000, "maxDataPoints": 43200
+				}
+			},
+			{
+				"refId": "C",
+				"queryType": "",
+				"relativeTimeRange": {"from": 0, "to": 0},
+				"datasourceUid": "__expr__",
+				"model": {"type": "threshold", "expression": "B", "conditions": [{"evaluator": {"type": "gt", "params": [0.5]}}]}
+			},
+			{
+				"refId": "D",
+				"queryType": "",
+				"relativeTimeRange": {"from": 0, "to": 0},
+				"datasourceUid": "__expr__",
+				"model": {"type": "classic_conditions", "conditions": [{
+					"type": "query",
+					"query": {"params": ["A"]},
+					"reducer": {"type": "avg"},
+					"evaluator": {"type": "outside_range", "params": [0, 1]},
+					"operator": {"type": "and"}
+				}]}
+			},
+			{
+				"refId": "E",
+				"queryType": "",
+				"relativeTimeRange": {"from": 0, "to": 0},
+				"datasourceUid": "__expr__",
+				"model": {"type": "math", "expression": "$B * 1000"}
+			}
+		],
+		"noDataState": "OK",
+		"execErrState": "Alerting",
+		"for": "5m",
+		"annotations": {"summary": "Latency is too high"},
+		"labels": {"team": "platform"},
+		"isPaused": false,
+		"provenance": "api"
+	}`)
+
+	var got AlertRule
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("AlertRule.UnmarshalJSON returned error %s", err)
+	}
+
+	expected := NewAlertRule("Latency SLO", "slo", "latency")
+	expected.UID = "slo-latency"
+	expected.OrgID = 1
+	expected.Condition = "C"
+	expected.NoDataState = OKAlertRuleState
+	expected.ExecErrState = AlertingAlertRuleErrorState
+	expected.For = "5m"
+	expected.Annotations["summary"] = "Latency is too high"
+	expected.Labels["team"] = "platform"
+	expected.Provenance = "api"
+	expected.Data = []*AlertRuleQuery{
+		NewAlertRuleQuery("A", "prometheus", 10*time.Minute,
+			json.RawMessage(`{"expr": "histogram_quantile(0.99, rate(latency_bucket[5m]))", "refId": "A"}`)),
+		NewExpressionQuery("B", &ReduceExpression{
+			Expression: "A",
+			Reducer:    MeanExpressionReducer,
+			Settings:   &ReduceSettings{Mode: DropNonNumbersMode},
+		}),
+		NewExpressionQuery("C", NewThresholdExpression("B", ExpressionEvaluator{Type: GreaterEvaluator, Params: []float64{0.5}})),
+		NewExpressionQuery("D", &ClassicConditionsExpression{Conditions: []ClassicCondition{
+			NewClassicCondition("A", "avg", ExpressionEvaluator{Type: OutsideRangeEvaluator, Params: []float64{0, 1}}),
+		}}),
+		NewExpressionQuery("E", &MathExpression{Expression: "$B * 1000"}),
+	}
+	// Fields of expressions that aren't modelled are checked by marshaling below
+	stripped := got
+	stripped.Data = make([]*AlertRuleQuery, len(got.Data))
+	for i, q := range got.Data {
+		sq := *q
+		sq.model = rawObject{}
+		stripped.Data[i] = &sq
+	}
+	if !reflect.DeepEqual(expected, &stripped) {
+		t.Errorf("AlertRule.UnmarshalJSON: %s", pretty.Diff(expected, &stripped))
+	}
+
+	b, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatalf("AlertRule.MarshalJSON returned error %s", err)
+	}
+	if eq, err := JSONBytesEqual(data, b); err != nil {
+		t.Fatalf("AlertRule.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("AlertRule.MarshalJSON:\ngot %s\nwant %s", b, data)
+	}
+
+	// Changes of expression are written over its model
+	got.Data[1].Model.(*ReduceExpression).Settings = nil
+	b, err = json.Marshal(got.Data[1])
+	if err != nil {
+		t.Fatalf("AlertRuleQuery.MarshalJSON returned error %s", err)
+	}
+	expectedQuery := []byte(`{
+		"refId": "B",
+		"queryType": "",
+		"relativeTimeRange": {"from": 0, "to": 0},
+		"datasourceUid": "__expr__",
+		"model": {
+			"type": "reduce", "expression": "A", "reducer": "mean",
+			"refId": "B", "datasource": {"type": "__expr__", "uid": "__expr__"}, "intervalMs": 1000, "maxDataPoints": 43200
+		}
+	}`)
+	if eq, err := JSONBytesEqual(expectedQuery, b); err != nil {
+		t.Fatalf("AlertRuleQuery.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("AlertRuleQuery.MarshalJSON:\ngot %s\nwant %s", b, expectedQuery)
+	}
+
+	// Expressions of unknown types are kept as they are
+	unknown := []byte(`{"refId":"A","queryType":"","relativeTimeRange":{"from":0,"to":0},"datasourceUid":"__expr__","model":{"type":"resample","expression":"B","window":"10s"}}`)
+	var q AlertRuleQuery
+	if err := json.Unmarshal(unknown, &q); err != nil {
+		t.Fatalf("AlertRuleQuery.UnmarshalJSON returned error %s", err)
+	}
+	if _, ok := q.Model.(json.RawMessage); !ok {
+		t.Errorf("AlertRuleQuery.UnmarshalJSON: got model %#v, want json.RawMessage", q.Model)
+	}
+	if b, err := json.Marshal(&q); err != nil {
+		t.Fatalf("AlertRuleQuery.MarshalJSON returned error %s", err)
+	} else if string(b) != string(unknown) {
+		t.Errorf("AlertRuleQuery.MarshalJSON:\ngot %s\nwant %s", b, unknown)
+	}
+}
+
+func TestAlertRuleGroup_JSON(t *testing.T) {
+	data := []byte(`{"title": "latency", "folderUid": "slo", "interval": 60, "rules": []}`)
+
+	var got AlertRuleGroup
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("AlertRuleGroup.UnmarshalJSON returned error %s", err)
+	}
+	expected := AlertRuleGroup{Title: "latency", FolderUID: "slo", Interval: time.Minute, Rules: []*AlertRule{}}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("AlertRuleGroup.UnmarshalJSON: %s", pretty.Diff(expected, got))
+	}
+
+	b, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatalf("AlertRuleGroup.MarshalJSON returned error %s", err)
+	}
+	if eq, err := JSONBytesEqual(data, b); err != nil {
+		t.Fatalf("AlertRuleGroup.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("AlertRuleGroup.MarshalJSON:\ngot %s\nwant %s", b, data)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AnnotationEventID is an ID type of AnnotationEvent
+type AnnotationEventID uint64
+
+// AnnotationEvent is an annotation stored in Grafana, e.g. a deploy marker.
+// Unlike Annotation that queries annotations for a dashboard, it marks a single
+// point or region of time on graphs. It's global unless DashboardUID is set.
+type AnnotationEvent struct {
+	ID           AnnotationEventID `json:"id,omitempty"`
+	AlertID      uint64            `json:"alertId,omitempty"`
+	DashboardID  DashboardID       `json:"dashboardId,omitempty"`
+	DashboardUID string            `json:"dashboardUID,omitempty"`
+	PanelID      uint              `json:"panelId,omitempty"`
+	UserID       UserID            `json:"userId,omitempty"`
+	NewState     string            `json:"newState,omitempty"`
+	PrevState    string            `json:"prevState,omitempty"`
+	Text         string            `json:"text"`
+	Tags         []string          `json:"tags"`
+	Login        string            `json:"login,omitempty"`
+	Email        string            `json:"email,omitempty"`
+	AvatarURL    string            `json:"avatarUrl,omitempty"`
+
+	Time    time.Time `json:"-"`
+	TimeEnd time.Time `json:"-"` // end of a region annotation, zero for a point one
+	Created time.Time `json:"-"`
+	Updated time.Time `json:"-"`
+}
+
+// IsRegion reports whether annotation marks a region of time.
+func (a *AnnotationEvent) IsRegion() bool {
+	return !a.TimeEnd.IsZero() && !a.TimeEnd.Equal(a.Time)
+}
+
+// jsonAnnotationEventTimes are times of AnnotationEvent as they're sent to the
+// API, i.e. in milliseconds since epoch.
+type jsonAnnotationEventTimes struct {
+	Time    int64 `json:"time,omitempty"`
+	TimeEnd int64 `json:"timeEnd,omitempty"`
+	Created int64 `json:"created,omitempty"`
+	Updated int64 `json:"updated,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (a *AnnotationEvent) MarshalJSON() ([]byte, error) {
+	type JSONAnnotationEvent AnnotationEvent
+	ja := struct {
+		*JSONAnnotationEvent
+		jsonAnnotationEventTimes
+	}{
+		JSONAnnotationEvent: (*JSONAnnotationEvent)(a),
+		jsonAnnotationEventTimes: jsonAnnotationEventTimes{
+			Time:    epochMillis(a.Time),
+			TimeEnd: epochMillis(a.TimeEnd),
+			Created: epochMillis(a.Created),
+			Updated: epochMillis(a.Updated),
+		},
+	}
+
+	return json.Marshal(ja)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *AnnotationEvent) UnmarshalJSON(data []byte) error {
+	type JSONAnnotationEvent AnnotationEvent
+	ja := struct {
+		*JSONAnnotationEvent
+		jsonAnnotationEventTimes
+	}{
+		JSONAnnotationEvent: (*JSONAnnotationEvent)(a),
+	}
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	a.Time = fromEpochMillis(ja.jsonAnnotationEventTimes.Time)
+	a.TimeEnd = fromEpochMillis(ja.jsonAnnotationEventTimes.TimeEnd)
+	a.Created = fromEpochMillis(ja.jsonAnnotationEventTimes.Created)
+	a.Updated = fromEpochMillis(ja.jsonAnnotationEventTimes.Updated)
+	return nil
+}
+
+// epochMillis returns t as milliseconds since epoch or 0 if t is zero.
+func epochMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// fromEpochMillis returns time for given milliseconds since epoch or zero time
+// if ms is 0.
+func fromEpochMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// AnnotationTag is a tag of annotations with a number of annotations having it.
+type AnnotationTag struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
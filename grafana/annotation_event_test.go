@@ -0,0 +1,84 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnnotationEvent_JSON(t *testing.T) {
+	data := `{
+		"id": 1,
+		"dashboardId": 2,
+		"dashboardUID": "uid",
+		"panelId": 3,
+		"userId": 4,
+		"text": "Deployed v1.2",
+		"tags": ["deploy"],
+		"login": "admin",
+		"time": 1507266395000,
+		"timeEnd": 1507266455000,
+		"created": 1507266395000,
+		"updated": 1507266396000
+	}`
+
+	var got AnnotationEvent
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("AnnotationEvent.UnmarshalJSON returned error %s", err)
+	}
+
+	start := time.Date(2017, 10, 6, 5, 6, 35, 0, time.UTC)
+	want := AnnotationEvent{
+		ID:           1,
+		DashboardID:  2,
+		DashboardUID: "uid",
+		PanelID:      3,
+		UserID:       4,
+		Text:         "Deployed v1.2",
+		Tags:         []string{"deploy"},
+		Login:        "admin",
+		Time:         start,
+		TimeEnd:      start.Add(time.Minute),
+		Created:      start,
+		Updated:      start.Add(time.Second),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AnnotationEvent.UnmarshalJSON:\ngot  %+v\nwant %+v", got, want)
+	}
+	if !got.IsRegion() {
+		t.Errorf("AnnotationEvent.IsRegion: expected region annotation")
+	}
+
+	b, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatalf("AnnotationEvent.MarshalJSON returned error %s", err)
+	}
+	if eq, err := JSONBytesEqual(b, []byte(data)); err != nil {
+		t.Fatalf("AnnotationEvent.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("AnnotationEvent.MarshalJSON: got %s, want %s", b, data)
+	}
+
+	// Zero times aren't sent, so that Grafana uses current time
+	b, err = json.Marshal(&AnnotationEvent{Text: "Point", Tags: []string{}})
+	if err != nil {
+		t.Fatalf("AnnotationEvent.MarshalJSON returned error %s", err)
+	}
+	if expected := `{"text":"Point","tags":[]}`; string(b) != expected {
+		t.Errorf("AnnotationEvent.MarshalJSON: got %s, want %s", b, expected)
+	}
+}
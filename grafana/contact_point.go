@@ -0,0 +1,235 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type contactPointType string
+
+// Types of contact points that have typed settings. Contact points of other
+// types keep their settings as json.RawMessage.
+const (
+	EmailContactPoint     contactPointType = "email"
+	SlackContactPoint     contactPointType = "slack"
+	PagerDutyContactPoint contactPointType = "pagerduty"
+	WebhookContactPoint   contactPointType = "webhook"
+	OpsgenieContactPoint  contactPointType = "opsgenie"
+)
+
+// ContactPoint is an integration of Grafana alerting that sends notifications,
+// e.g. to Slack channel. Contact points with the same name form a single
+// receiver of notification policies.
+type ContactPoint struct {
+	UID                   string           `json:"uid,omitempty"`
+	Name                  string           `json:"name"`
+	Type                  contactPointType `json:"type"`
+	DisableResolveMessage bool             `json:"disableResolveMessage"`
+	Provenance            string           `json:"provenance,omitempty"`
+
+	// Settings are settings of integration of Type, e.g. *SlackSettings, or
+	// json.RawMessage for types without typed settings. Secrets are returned
+	// by the API as "[REDACTED]".
+	Settings interface{} `json:"settings"`
+
+	// settings keeps fields of unmarshaled typed settings that they don't have
+	settings rawObject
+}
+
+// ContactPointSettings are typed settings of a contact point.
+type ContactPointSettings interface {
+	ContactPointType() contactPointType
+}
+
+// NewContactPoint creates a new ContactPoint with given name and settings of
+// integration.
+func NewContactPoint(name string, settings ContactPointSettings) *ContactPoint {
+	return &ContactPoint{
+		Name:     name,
+		Type:     settings.ContactPointType(),
+		Settings: settings,
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (c *ContactPoint) MarshalJSON() ([]byte, error) {
+	type JSONContactPoint ContactPoint
+	jc := JSONContactPoint(*c)
+	if typed, ok := c.Settings.(ContactPointSettings); ok {
+		settings, err := c.settings.encode(typed)
+		if err != nil {
+			return nil, err
+		}
+		jc.Settings = json.RawMessage(settings)
+	}
+	return json.Marshal(jc)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (c *ContactPoint) UnmarshalJSON(data []byte) error {
+	type JSONContactPoint ContactPoint
+	var settings json.RawMessage
+	jc := JSONContactPoint{Settings: &settings}
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return err
+	}
+
+	*c = ContactPoint(jc)
+	typed, err := contactPointSettings(c.Type, settings, &c.settings)
+	if err != nil {
+		return err
+	}
+	c.Settings = typed
+	return nil
+}
+
+// contactPointSettings decodes settings of integration of given type into its
+// typed settings keeping their JSON in raw. Settings of unknown types are
+// returned as they are.
+func contactPointSettings(typ contactPointType, settings json.RawMessage, raw *rawObject) (interface{}, error) {
+	var typed ContactPointSettings
+	switch typ {
+	case EmailContactPoint:
+		typed = new(EmailSettings)
+	case SlackContactPoint:
+		typed = new(SlackSettings)
+	case PagerDutyContactPoint:
+		typed = new(PagerDutySettings)
+	case WebhookContactPoint:
+		typed = new(WebhookSettings)
+	case OpsgenieContactPoint:
+		typed = new(OpsgenieSettings)
+	default:
+		return settings, nil
+	}
+	if len(settings) > 0 {
+		if err := raw.decode(settings, typed); err != nil {
+			return nil, err
+		}
+	}
+	return typed, nil
+}
+
+// EmailSettings are settings of email contact point.
+type EmailSettings struct {
+	Addresses   EmailAddresses `json:"addresses"`
+	SingleEmail bool           `json:"singleEmail"` // send a single email to all addresses
+	Subject     string         `json:"subject,omitempty"`
+	Message     string         `json:"message,omitempty"`
+}
+
+// ContactPointType implements ContactPointSettings interface
+func (s *EmailSettings) ContactPointType() contactPointType {
+	return EmailContactPoint
+}
+
+// EmailAddresses is a list of email addresses. The API keeps them in a single
+// string separated by semicolons, commas or new lines.
+type EmailAddresses []string
+
+// MarshalJSON implements json.Marshaler interface
+func (a EmailAddresses) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(a, ";"))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *EmailAddresses) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*a = EmailAddresses{}
+	for _, addr := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ',' || r == '\n' }) {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			*a = append(*a, addr)
+		}
+	}
+	return nil
+}
+
+// SlackSettings are settings of Slack contact point. Either URL of incoming
+// webhook or Token of Slack app with Recipient should be set.
+type SlackSettings struct {
+	URL            string `json:"url,omitempty"`
+	Token          string `json:"token,omitempty"`
+	Recipient      string `json:"recipient,omitempty"` // channel or user
+	Username       string `json:"username,omitempty"`
+	IconEmoji      string `json:"icon_emoji,omitempty"`
+	IconURL        string `json:"icon_url,omitempty"`
+	MentionChannel string `json:"mentionChannel,omitempty"` // here or channel
+	MentionUsers   string `json:"mentionUsers,omitempty"`
+	MentionGroups  string `json:"mentionGroups,omitempty"`
+	Title          string `json:"title,omitempty"`
+	Text           string `json:"text,omitempty"`
+}
+
+// ContactPointType implements ContactPointSettings interface
+func (s *SlackSettings) ContactPointType() contactPointType {
+	return SlackContactPoint
+}
+
+// PagerDutySettings are settings of PagerDuty contact point.
+type PagerDutySettings struct {
+	IntegrationKey string `json:"integrationKey"`
+	Severity       string `json:"severity,omitempty"` // critical, error, warning or info
+	Class          string `json:"class,omitempty"`
+	Component      string `json:"component,omitempty"`
+	Group          string `json:"group,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	Source         string `json:"source,omitempty"`
+	Client         string `json:"client,omitempty"`
+	ClientURL      string `json:"client_url,omitempty"`
+}
+
+// ContactPointType implements ContactPointSettings interface
+func (s *PagerDutySettings) ContactPointType() contactPointType {
+	return PagerDutyContactPoint
+}
+
+// WebhookSettings are settings of webhook contact point.
+type WebhookSettings struct {
+	URL                      string `json:"url"`
+	HTTPMethod               string `json:"httpMethod,omitempty"` // POST or PUT
+	Username                 string `json:"username,omitempty"`
+	Password                 string `json:"password,omitempty"`
+	AuthorizationScheme      string `json:"authorization_scheme,omitempty"`
+	AuthorizationCredentials string `json:"authorization_credentials,omitempty"`
+	MaxAlerts                int    `json:"maxAlerts,omitempty"`
+	Title                    string `json:"title,omitempty"`
+	Message                  string `json:"message,omitempty"`
+}
+
+// ContactPointType implements ContactPointSettings interface
+func (s *WebhookSettings) ContactPointType() contactPointType {
+	return WebhookContactPoint
+}
+
+// OpsgenieSettings are settings of Opsgenie contact point.
+type OpsgenieSettings struct {
+	APIKey           string `json:"apiKey"`
+	APIURL           string `json:"apiUrl,omitempty"`
+	Message          string `json:"message,omitempty"`
+	Description      string `json:"description,omitempty"`
+	AutoClose        bool   `json:"autoClose"`
+	OverridePriority bool   `json:"overridePriority"`
+	SendTagsAs       string `json:"sendTagsAs,omitempty"` // tags, details or both
+}
+
+// ContactPointType implements ContactPointSettings interface
+func (s *OpsgenieSettings) ContactPointType() contactPointType {
+	return OpsgenieContactPoint
+}
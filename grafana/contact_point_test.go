@@ -0,0 +1,86 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func TestContactPoint_JSON(t *testing.T) {
+	data := []byte(`[
+		{"uid": "email", "name": "ops", "type": "email", "disableResolveMessage": false,
+			"settings": {"addresses": "ops@example.com;oncall@example.com", "singleEmail": true}},
+		{"uid": "slack", "name": "ops", "type": "slack", "disableResolveMessage": true,
+			"settings": {"recipient": "#ops", "token": "[REDACTED]", "mentionChannel": "here", "endpointUrl": "https://slack.example.com/api/chat.postMessage"}},
+		{"uid": "pd", "name": "oncall", "type": "pagerduty", "disableResolveMessage": false, "provenance": "api",
+			"settings": {"integrationKey": "[REDACTED]", "severity": "critical"}},
+		{"uid": "tg", "name": "chat", "type": "telegram", "disableResolveMessage": false,
+			"settings": {"chatid": "123", "bottoken": "[REDACTED]"}}
+	]`)
+
+	var got []*ContactPoint
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("ContactPoint.UnmarshalJSON returned error %s", err)
+	}
+
+	email := NewContactPoint("ops", &EmailSettings{Addresses: EmailAddresses{"ops@example.com", "oncall@example.com"}, SingleEmail: true})
+	email.UID = "email"
+	slack := NewContactPoint("ops", &SlackSettings{Recipient: "#ops", Token: "[REDACTED]", MentionChannel: "here"})
+	slack.UID = "slack"
+	slack.DisableResolveMessage = true
+	pagerduty := NewContactPoint("oncall", &PagerDutySettings{IntegrationKey: "[REDACTED]", Severity: "critical"})
+	pagerduty.UID = "pd"
+	pagerduty.Provenance = "api"
+	telegram := &ContactPoint{
+		UID:      "tg",
+		Name:     "chat",
+		Type:     "telegram",
+		Settings: json.RawMessage(`{"chatid": "123", "bottoken": "[REDACTED]"}`),
+	}
+	expected := []*ContactPoint{email, slack, pagerduty, telegram}
+	// Settings without fields, ie. endpointUrl, are checked by marshaling below
+	stripped := make([]*ContactPoint, len(got))
+	for i, c := range got {
+		sc := *c
+		sc.settings = rawObject{}
+		stripped[i] = &sc
+	}
+	if !reflect.DeepEqual(expected, stripped) {
+		t.Errorf("ContactPoint.UnmarshalJSON: %s", pretty.Diff(expected, stripped))
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("ContactPoint.MarshalJSON returned error %s", err)
+	}
+	if eq, err := JSONBytesEqual(data, b); err != nil {
+		t.Fatalf("ContactPoint.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("ContactPoint.MarshalJSON:\ngot %s\nwant %s", b, data)
+	}
+}
+
+func TestEmailAddresses_UnmarshalJSON(t *testing.T) {
+	var got EmailAddresses
+	if err := json.Unmarshal([]byte(`"a@example.com, b@example.com\nc@example.com;"`), &got); err != nil {
+		t.Fatalf("EmailAddresses.UnmarshalJSON returned error %s", err)
+	}
+	if expected := (EmailAddresses{"a@example.com", "b@example.com", "c@example.com"}); !reflect.DeepEqual(expected, got) {
+		t.Errorf("EmailAddresses.UnmarshalJSON: got %v, want %v", got, expected)
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/utilitywarehouse/go-grafana/grafana/panel"
 	panelQuery "github.com/utilitywarehouse/go-grafana/grafana/query"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 type (
@@ -33,54 +34,26 @@ const (
 )
 
 type Dashboard struct {
-	Annotations struct {
-		List []interface{} `json:"list"`
-	} `json:"annotations"`
-	Editable      bool            `json:"editable"`
-	GraphTooltip  int             `json:"graphTooltip"`
-	HideControls  bool            `json:"hideControls"`
-	ID            DashboardID     `json:"-"`
-	Links         []interface{}   `json:"links"`
-	Refresh       interface{}     `json:"refresh"`
-	Rows          []*DashboardRow `json:"rows"`
-	SchemaVersion int             `json:"schemaVersion"`
-	Style         dashboardStyle  `json:"style"`
-	Tags          []string        `json:"tags"`
-	Templating    struct {
-		List []struct {
-			Auto      bool   `json:"auto"`
-			AutoCount int    `json:"auto_count"`
-			AutoMin   string `json:"auto_min"`
-			Current   struct {
-				Text  string      `json:"text"`
-				Value interface{} `json:"value"`
-			} `json:"current"`
-			Hide    int         `json:"hide"`
-			Label   interface{} `json:"label"`
-			Name    string      `json:"name"`
-			Options []struct {
-				Selected bool        `json:"selected"`
-				Text     string      `json:"text"`
-				Value    interface{} `json:"value"`
-			} `json:"options"`
-			Query   string `json:"query"`
-			Refresh int    `json:"refresh"`
-			Type    string `json:"type"`
-		} `json:"list"`
-	} `json:"templating"`
-	Time struct {
-		From string `json:"from"`
-		To   string `json:"to"`
-	} `json:"time"`
-	Timepicker struct {
-		RefreshIntervals []string `json:"refresh_intervals"`
-		TimeOptions      []string `json:"time_options"`
-	} `json:"timepicker"`
-	Timezone string         `json:"timezone"`
-	Title    string         `json:"title"`
-	UID      string         `json:"uid"`
-	Version  uint64         `json:"-"`
-	Meta     *DashboardMeta `json:"meta"`
+	Annotations   Annotations      `json:"annotations"`
+	Editable      bool             `json:"editable"`
+	GraphTooltip  int              `json:"graphTooltip"`
+	HideControls  bool             `json:"hideControls"`
+	ID            DashboardID      `json:"-"`
+	Links         []*DashboardLink `json:"links"`
+	Panels        Panels           `json:"panels,omitempty"`
+	Refresh       Refresh          `json:"refresh"`
+	Rows          []*Row           `json:"rows,omitempty"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Style         dashboardStyle   `json:"style"`
+	Tags          []string         `json:"tags"`
+	Templating    Variables        `json:"templating"`
+	Time          TimeRange        `json:"time"`
+	Timepicker    Timepicker       `json:"timepicker"`
+	Timezone      string           `json:"timezone"`
+	Title         string           `json:"title"`
+	UID           string           `json:"uid"`
+	Version       uint64           `json:"version,omitempty"` // checked by Grafana on save to not overwrite changes of others
+	Meta          *DashboardMeta   `json:"meta"`
 }
 
 // NewDashboard creates new Dashboard.
@@ -91,6 +64,7 @@ func NewDashboard(title string) *Dashboard {
 		SchemaVersion: 14,
 		Style:         dashboardDarkStyle,
 		Tags:          []string{},
+		Time:          NewTimeRange("now-6h", "now"),
 	}
 }
 
@@ -115,88 +89,132 @@ type DashboardMeta struct {
 	FolderURL   string    `json:"folderUrl"`
 }
 
-type DashboardRow struct {
-	Collapse bool        `json:"collapse"`
-	Height   interface{} `json:"height"`
-	Panels   []struct {
-		AliasColors struct {
-		} `json:"aliasColors"`
-		Bars       bool   `json:"bars"`
-		DashLength int    `json:"dashLength"`
-		Dashes     bool   `json:"dashes"`
-		Datasource string `json:"datasource"`
-		Editable   bool   `json:"editable"`
-		Error      bool   `json:"error"`
-		Fill       int    `json:"fill"`
-		ID         int    `json:"id"`
-		Legend     struct {
-			Avg     bool `json:"avg"`
-			Current bool `json:"current"`
-			Max     bool `json:"max"`
-			Min     bool `json:"min"`
-			Show    bool `json:"show"`
-			Total   bool `json:"total"`
-			Values  bool `json:"values"`
-		} `json:"legend"`
-		Lines           bool          `json:"lines"`
-		Linewidth       int           `json:"linewidth"`
-		Links           []interface{} `json:"links"`
-		NullPointMode   string        `json:"nullPointMode"`
-		Percentage      bool          `json:"percentage"`
-		Pointradius     int           `json:"pointradius"`
-		Points          bool          `json:"points"`
-		Renderer        string        `json:"renderer"`
-		SeriesOverrides []interface{} `json:"seriesOverrides"`
-		SpaceLength     int           `json:"spaceLength"`
-		Span            float64       `json:"span"`
-		Stack           bool          `json:"stack"`
-		SteppedLine     bool          `json:"steppedLine"`
-		Targets         []struct {
-			Expr           string `json:"expr"`
-			Format         string `json:"format"`
-			Interval       string `json:"interval"`
-			IntervalFactor int    `json:"intervalFactor"`
-			LegendFormat   string `json:"legendFormat"`
-			Metric         string `json:"metric"`
-			RefID          string `json:"refId"`
-			Step           int    `json:"step"`
-		} `json:"targets"`
-		Thresholds interface{} `json:"thresholds"`
-		TimeFrom   interface{} `json:"timeFrom"`
-		TimeShift  interface{} `json:"timeShift"`
-		Title      string      `json:"title"`
-		Tooltip    struct {
-			MsResolution bool   `json:"msResolution"`
-			Shared       bool   `json:"shared"`
-			Sort         int    `json:"sort"`
-			ValueType    string `json:"value_type"`
-		} `json:"tooltip"`
-		Type  string `json:"type"`
-		Xaxis struct {
-			Buckets interface{}   `json:"buckets"`
-			Mode    string        `json:"mode"`
-			Name    interface{}   `json:"name"`
-			Show    bool          `json:"show"`
-			Values  []interface{} `json:"values"`
-		} `json:"xaxis"`
-		Yaxes []struct {
-			Format  string      `json:"format"`
-			Label   string      `json:"label"`
-			LogBase int         `json:"logBase"`
-			Max     interface{} `json:"max"`
-			Min     interface{} `json:"min"`
-			Show    bool        `json:"show"`
-		} `json:"yaxes"`
-	} `json:"panels"`
-	Repeat          interface{} `json:"repeat"`
-	RepeatIteration interface{} `json:"repeatIteration"`
-	RepeatRowID     interface{} `json:"repeatRowId"`
-	ShowTitle       bool        `json:"showTitle"`
-	Title           string      `json:"title"`
-	TitleSize       string      `json:"titleSize"`
+// AssignPanelIDs assigns unique IDs to panels of the dashboard that don't have
+// one or share it with a previous panel. IDs of the other panels are kept, so
+// links to them stay valid after dashboard's update.
+func (d *Dashboard) AssignPanelIDs() {
+	panels := d.allPanels()
+
+	var maxID uint
+	for _, p := range panels {
+		if id := p.GeneralOptions().ID; id > maxID {
+			maxID = id
+		}
+	}
+
+	seen := make(map[uint]bool, len(panels))
+	for _, p := range panels {
+		opts := p.GeneralOptions()
+		if opts.ID == 0 || seen[opts.ID] {
+			maxID++
+			opts.ID = maxID
+		}
+		seen[opts.ID] = true
+	}
+}
+
+// Validate checks the dashboard, its panels, queries and variables. It returns
+// validate.Errors that lists all found problems, each with a JSON pointer to
+// invalid value, ie. /panels/2/targets/0/expr. Overlapping of panels is checked
+// by ValidateLayout.
+func (d *Dashboard) Validate() error {
+	var errs validate.Errors
+	if d.Title == "" {
+		errs.Add("/title", "should not be empty")
+	}
+	if d.GraphTooltip < 0 || d.GraphTooltip > 2 {
+		errs.Add("/graphTooltip", "should be 0 (default), 1 (shared crosshair) or 2 (shared tooltip), got %d", d.GraphTooltip)
+	}
+	switch d.Style {
+	case "", dashboardDarkStyle, dashboardLightStyle:
+	default:
+		errs.Add("/style", "should be %s or %s, got %q", dashboardDarkStyle, dashboardLightStyle, d.Style)
+	}
+	if _, err := d.Refresh.Duration(); err != nil {
+		errs.Add("/refresh", "%s", err)
+	}
+	errs.Merge("/time", d.Time.Validate())
+	errs.Merge("/timepicker", d.Timepicker.Validate())
+	for i, link := range d.Links {
+		errs.Merge(validate.Path("links", i), link.Validate())
+	}
+
+	names := make(map[string]bool, len(d.Templating))
+	for i, v := range d.Templating {
+		path := validate.Path("templating", "list", i)
+		if vv, ok := v.(validator); ok {
+			errs.Merge(path, vv.Validate())
+		}
+		if name := v.commonOptions().Name; names[name] {
+			errs.Add(path+"/name", "duplicates name of another variable %q", name)
+		} else {
+			names[name] = true
+		}
+	}
+
+	for i, row := range d.Rows {
+		errs.Merge(validate.Path("rows", i), row.Validate())
+	}
+	validatePanels(&errs, "/panels", d.Panels)
+
+	// Panel IDs are used in links to panels, so they should be unique
+	ids := make(map[uint]string)
+	d.walkPanels(func(path string, p Panel) {
+		id := p.GeneralOptions().ID
+		if id == 0 {
+			return
+		}
+		if prev, ok := ids[id]; ok {
+			errs.Add(path+"/id", "duplicates id of %s", prev)
+			return
+		}
+		ids[id] = path
+	})
+
+	return errs.Err()
 }
 
-//Panel represents Dashboard's panel
+// validator is implemented by entities of dashboard that can be validated.
+type validator interface {
+	Validate() error
+}
+
+// validatePanels validates panels that implement validator interface.
+func validatePanels(errs *validate.Errors, path string, panels Panels) {
+	for i, p := range panels {
+		if v, ok := p.(validator); ok {
+			errs.Merge(path+validate.Path(i), v.Validate())
+		}
+	}
+}
+
+// walkPanels calls fn for all panels of the dashboard with JSON pointers to them.
+func (d *Dashboard) walkPanels(fn func(path string, p Panel)) {
+	for i, row := range d.Rows {
+		for j, p := range row.Panels {
+			fn(validate.Path("rows", i, "panels", j), p)
+		}
+	}
+	for i, p := range d.Panels {
+		fn(validate.Path("panels", i), p)
+		if row, ok := p.(*RowPanel); ok {
+			for j, child := range row.Panels {
+				fn(validate.Path("panels", i, "panels", j), child)
+			}
+		}
+	}
+}
+
+// allPanels returns all panels of the dashboard including panels of rows.
+func (d *Dashboard) allPanels() []Panel {
+	var panels []Panel
+	d.walkPanels(func(_ string, p Panel) {
+		panels = append(panels, p)
+	})
+	return panels
+}
+
+// Panel represents Dashboard's panel
 type Panel interface {
 	GeneralOptions() *panel.GeneralOptions
 }
@@ -207,10 +225,42 @@ const (
 	textPanelType       panelType = "text"
 	singlestatPanelType panelType = "singlestat"
 	graphPanelType      panelType = "graph"
+	rowPanelType        panelType = "row"
 )
 
+// Panels is a list of dashboard's panels.
+type Panels []Panel
+
+// MarshalJSON implements json.Marshaler interface
+func (p Panels) MarshalJSON() ([]byte, error) {
+	panels := make([]*probePanel, len(p))
+	for i, pp := range p {
+		panels[i] = &probePanel{panel: pp}
+	}
+
+	return json.Marshal(panels)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (p *Panels) UnmarshalJSON(data []byte) error {
+	var probes []*probePanel
+	if err := json.Unmarshal(data, &probes); err != nil {
+		return err
+	}
+
+	panels := make(Panels, 0, len(probes))
+	for _, pp := range probes {
+		if pp == nil {
+			continue
+		}
+		panels = append(panels, pp.panel)
+	}
+	*p = panels
+
+	return nil
+}
+
 type probePanel struct {
-	ID   uint      `json:"id"`
 	Type panelType `json:"type"`
 
 	panel Panel
@@ -239,7 +289,15 @@ func (p *probePanel) UnmarshalJSON(data []byte) error {
 		pp = new(panel.Singlestat)
 	case graphPanelType:
 		pp = new(panel.Graph)
+	case rowPanelType:
+		pp = new(RowPanel)
 	default:
+		// Panels of unknown types are kept as they are
+		raw := new(RawPanel)
+		if err := json.Unmarshal(data, raw); err != nil {
+			return err
+		}
+		p.panel = raw
 		return nil
 	}
 
@@ -267,6 +325,10 @@ func (p *probePanel) UnmarshalJSON(data []byte) error {
 			if q.query == nil {
 				continue
 			}
+			// Queries without datasource use the panel's one
+			if ref := queriesOpts.Datasource; q.Datasource == nil && ref != nil && !isMixedDatasource(*ref) {
+				q.query.SetDatasource(*ref)
+			}
 			newQueries = append(newQueries, q.query)
 		}
 		*queriesPtr = newQueries
@@ -278,6 +340,10 @@ func (p *probePanel) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON implements json.Marshaler interface
 func (p *probePanel) MarshalJSON() ([]byte, error) {
+	if raw, ok := p.panel.(*RawPanel); ok {
+		return raw.MarshalJSON()
+	}
+
 	type JSONPanel probePanel
 	jp := struct {
 		*JSONPanel
@@ -285,6 +351,7 @@ func (p *probePanel) MarshalJSON() ([]byte, error) {
 		*panel.Text
 		*panel.Singlestat
 		*panel.Graph
+		*RowPanel
 
 		*panel.GeneralOptions
 		*queriesOptions
@@ -303,6 +370,9 @@ func (p *probePanel) MarshalJSON() ([]byte, error) {
 	case *panel.Graph:
 		jp.Graph = v
 		jp.Type = graphPanelType
+	case *RowPanel:
+		jp.RowPanel = v
+		jp.Type = rowPanelType
 	}
 
 	if qp, ok := p.panel.(QueryablePanel); ok {
@@ -315,26 +385,37 @@ func (p *probePanel) MarshalJSON() ([]byte, error) {
 			}
 		}
 
+		// Since schemaVersion 33 Grafana refers datasource of each query as well
+		byRef := false
+		for _, q := range queries {
+			if q.Datasource().UID != "" {
+				byRef = true
+			}
+		}
+
+		refIDs := makeRefIDs(queries)
 		probeQueries := make([]probeQuery, len(queries))
 		for i, q := range queries {
 			pq := probeQuery{
-				RefID: makeRefID(i),
+				RefID: refIDs[i],
 				query: q,
 			}
 
-			if isOwnDatasource {
-				pq.Datasource = q.Datasource()
+			if ref := q.Datasource(); (isOwnDatasource || byRef) && ref != (DatasourceRef{}) {
+				pq.Datasource = &ref
 			}
 			probeQueries[i] = pq
 		}
 
-		var datasource string
+		var datasource *DatasourceRef
 		if isOwnDatasource {
-			datasource = mixedDatasource
-		} else {
-			if len(queries) > 0 {
-				datasource = queries[0].Datasource()
+			datasource = &DatasourceRef{Name: mixedDatasource}
+			if byRef {
+				datasource = &DatasourceRef{UID: mixedDatasource, Type: builtInDatasourceType}
 			}
+		} else if len(queries) > 0 && queries[0].Datasource() != (DatasourceRef{}) {
+			ref := queries[0].Datasource()
+			datasource = &ref
 		}
 
 		jp.queriesOptions = &queriesOptions{
@@ -354,15 +435,21 @@ type QueryablePanel interface {
 const mixedDatasource = "-- Mixed --"
 
 type queriesOptions struct {
-	Datasource string       `json:"datasource,omitempty"`
-	Queries    []probeQuery `json:"targets"`
+	Datasource *DatasourceRef `json:"datasource,omitempty"`
+	Queries    []probeQuery   `json:"targets"`
+}
+
+// isMixedDatasource reports whether ref refers the built-in datasource of panels
+// which queries use their own datasources.
+func isMixedDatasource(ref DatasourceRef) bool {
+	return ref.Name == mixedDatasource || ref.UID == mixedDatasource
 }
 
 // probeQuery is an auxiliary entity thats purpose to manage marshaling and unmarshal of panel's query into concrete
 // types.
 type probeQuery struct {
-	RefID      string `json:"refid"`
-	Datasource string `json:"datasource,omitempty"`
+	RefID      string         `json:"refId"`
+	Datasource *DatasourceRef `json:"datasource,omitempty"`
 
 	query panel.Query
 }
@@ -404,6 +491,12 @@ func (q *probeQuery) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &query); err != nil {
 		return err
 	}
+	if r, ok := query.(panel.QueryRef); ok {
+		r.SetRefID(q.RefID)
+	}
+	if q.Datasource != nil {
+		query.SetDatasource(*q.Datasource)
+	}
 
 	q.query = query
 	return nil
@@ -430,21 +523,39 @@ func (q *probeQuery) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jq)
 }
 
-// makeRefID returns symbolic ID for given index.
-// TODO: It has very rough implementation. Needs refactoring.
-func makeRefID(index int) string {
-	letters := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+// makeRefIDs returns reference IDs of given queries. Queries keep their own refIDs,
+// the others get the first unused ones of A, B, ..., Z, AA, AB and so on.
+func makeRefIDs(queries []panel.Query) []string {
+	refIDs := make([]string, len(queries))
+	used := make(map[string]bool, len(queries))
+	for i, q := range queries {
+		if r, ok := q.(panel.QueryRef); ok && r.RefID() != "" {
+			refIDs[i] = r.RefID()
+			used[refIDs[i]] = true
+		}
+	}
 
-	var id string
-	if index >= len(letters) {
-		id += makeRefID(index % len(letters))
-	} else {
-		id = string(letters[index])
+	next := 0
+	for i := range refIDs {
+		if refIDs[i] != "" {
+			continue
+		}
+		for used[makeRefID(next)] {
+			next++
+		}
+		refIDs[i] = makeRefID(next)
+		used[refIDs[i]] = true
 	}
 
-	var result string
-	for _, v := range id {
-		result = string(v) + result
+	return refIDs
+}
+
+// makeRefID returns symbolic ID for given index: A-Z for indexes 0-25, then AA, AB
+// and so on (bijective base-26 numeration).
+func makeRefID(index int) string {
+	var id []byte
+	for n := index + 1; n > 0; n = (n - 1) / 26 {
+		id = append([]byte{byte('A' + (n-1)%26)}, id...)
 	}
-	return result
+	return string(id)
 }
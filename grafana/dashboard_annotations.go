@@ -0,0 +1,331 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import "encoding/json"
+
+// Annotations is a list of dashboard's annotation queries.
+type Annotations []Annotation
+
+// MarshalJSON implements json.Marshaler interface
+func (a Annotations) MarshalJSON() ([]byte, error) {
+	annotations := make([]*probeAnnotation, len(a))
+	for i, aa := range a {
+		annotations[i] = &probeAnnotation{annotation: aa}
+	}
+
+	ja := struct {
+		List []*probeAnnotation `json:"list"`
+	}{
+		List: annotations,
+	}
+
+	return json.Marshal(ja)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *Annotations) UnmarshalJSON(data []byte) error {
+	ja := struct {
+		List []*probeAnnotation `json:"list"`
+	}{}
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	annotations := make(Annotations, 0, len(ja.List))
+	for _, pa := range ja.List {
+		if pa == nil {
+			continue
+		}
+		annotations = append(annotations, pa.annotation)
+	}
+	*a = annotations
+
+	return nil
+}
+
+// Annotation is a dashboard's annotation query.
+type Annotation interface {
+	commonOptions() *commonAnnotationOptions
+}
+
+type commonAnnotationOptions struct {
+	Datasource DatasourceRef `json:"datasource"`
+	Enable     bool          `json:"enable"`
+	Hide       bool          `json:"hide"`
+	IconColor  string        `json:"iconColor"` // ie. rgba(0, 211, 255, 1)
+	Name       string        `json:"name"`
+}
+
+// builtInAnnotationType is a type of Grafana's built-in annotation query.
+type builtInAnnotationType string
+
+// Types of Grafana's built-in annotation query.
+const (
+	DashboardAnnotationType builtInAnnotationType = "dashboard"
+	TagsAnnotationType      builtInAnnotationType = "tags"
+)
+
+const (
+	builtInAnnotationsDatasource = builtInGrafanaDatasource
+	defaultAnnotationsLimit      = 100
+	defaultAnnotationIconColor   = "rgba(0, 211, 255, 1)"
+)
+
+// BuiltInAnnotation is an annotation query to Grafana's own annotations, either
+// of this dashboard or of all dashboards filtered by tags.
+type BuiltInAnnotation struct {
+	BuiltIn  uint8                 `json:"builtIn,omitempty"` // 1 for "Annotations & Alerts" query that every dashboard has
+	Type     builtInAnnotationType `json:"type"`
+	Tags     []string              `json:"tags,omitempty"`
+	Limit    uint                  `json:"limit,omitempty"`
+	MatchAny bool                  `json:"matchAny,omitempty"`
+
+	// Target duplicates filter options in dashboards saved by Grafana 8+
+	Target *BuiltInAnnotationTarget `json:"target,omitempty"`
+
+	commonAnnotationOptions
+	raw rawObject // keeps fields of the query that aren't modelled
+}
+
+// BuiltInAnnotationTarget is a filter of Grafana's built-in annotation query.
+type BuiltInAnnotationTarget struct {
+	Limit    uint                  `json:"limit"`
+	MatchAny bool                  `json:"matchAny"`
+	Tags     []string              `json:"tags"`
+	Type     builtInAnnotationType `json:"type"`
+}
+
+// NewDashboardAnnotation creates annotation query to annotations of this dashboard.
+func NewDashboardAnnotation(name string) *BuiltInAnnotation {
+	return &BuiltInAnnotation{
+		Type:                    DashboardAnnotationType,
+		Limit:                   defaultAnnotationsLimit,
+		commonAnnotationOptions: newCommonAnnotationOptions(name, DatasourceRef{Name: builtInAnnotationsDatasource}),
+	}
+}
+
+// NewTagsAnnotation creates annotation query to annotations of all dashboards
+// that have given tags.
+func NewTagsAnnotation(name string, tags ...string) *BuiltInAnnotation {
+	return &BuiltInAnnotation{
+		Type:                    TagsAnnotationType,
+		Tags:                    tags,
+		Limit:                   defaultAnnotationsLimit,
+		commonAnnotationOptions: newCommonAnnotationOptions(name, DatasourceRef{Name: builtInAnnotationsDatasource}),
+	}
+}
+
+func (a *BuiltInAnnotation) commonOptions() *commonAnnotationOptions {
+	return &a.commonAnnotationOptions
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (a *BuiltInAnnotation) MarshalJSON() ([]byte, error) {
+	type JSONAnnotation BuiltInAnnotation
+	return a.raw.encode((*JSONAnnotation)(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *BuiltInAnnotation) UnmarshalJSON(data []byte) error {
+	type JSONAnnotation BuiltInAnnotation
+	return a.raw.decode(data, (*JSONAnnotation)(a))
+}
+
+// PrometheusAnnotation is an annotation query to Prometheus datasource. Every
+// series returned by the expression becomes an annotation.
+type PrometheusAnnotation struct {
+	Expr            string `json:"expr"`
+	Step            string `json:"step,omitempty"`
+	TitleFormat     string `json:"titleFormat,omitempty"`
+	TagKeys         string `json:"tagKeys,omitempty"` // comma separated labels
+	TextFormat      string `json:"textFormat,omitempty"`
+	UseValueForTime bool   `json:"useValueForTime,omitempty"`
+
+	commonAnnotationOptions
+	raw rawObject // keeps fields of the query that aren't modelled
+}
+
+// NewPrometheusAnnotation creates annotation query to Prometheus datasource.
+func NewPrometheusAnnotation(name string, datasource DatasourceRef) *PrometheusAnnotation {
+	return &PrometheusAnnotation{
+		commonAnnotationOptions: newCommonAnnotationOptions(name, datasource),
+	}
+}
+
+func (a *PrometheusAnnotation) commonOptions() *commonAnnotationOptions {
+	return &a.commonAnnotationOptions
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (a *PrometheusAnnotation) MarshalJSON() ([]byte, error) {
+	type JSONAnnotation PrometheusAnnotation
+	return a.raw.encode((*JSONAnnotation)(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *PrometheusAnnotation) UnmarshalJSON(data []byte) error {
+	type JSONAnnotation PrometheusAnnotation
+	return a.raw.decode(data, (*JSONAnnotation)(a))
+}
+
+// LokiAnnotation is an annotation query to Loki datasource. Every log line
+// returned by the expression becomes an annotation.
+type LokiAnnotation struct {
+	Expr        string `json:"expr"`
+	TitleFormat string `json:"titleFormat,omitempty"`
+	TagKeys     string `json:"tagKeys,omitempty"` // comma separated labels
+	TextFormat  string `json:"textFormat,omitempty"`
+
+	commonAnnotationOptions
+	raw rawObject // keeps fields of the query that aren't modelled
+}
+
+// NewLokiAnnotation creates annotation query to Loki datasource.
+func NewLokiAnnotation(name string, datasource DatasourceRef) *LokiAnnotation {
+	return &LokiAnnotation{
+		commonAnnotationOptions: newCommonAnnotationOptions(name, datasource),
+	}
+}
+
+func (a *LokiAnnotation) commonOptions() *commonAnnotationOptions {
+	return &a.commonAnnotationOptions
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (a *LokiAnnotation) MarshalJSON() ([]byte, error) {
+	type JSONAnnotation LokiAnnotation
+	return a.raw.encode((*JSONAnnotation)(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *LokiAnnotation) UnmarshalJSON(data []byte) error {
+	type JSONAnnotation LokiAnnotation
+	return a.raw.decode(data, (*JSONAnnotation)(a))
+}
+
+// ElasticsearchAnnotation is an annotation query to Elasticsearch datasource.
+// Every document matched by the query becomes an annotation.
+type ElasticsearchAnnotation struct {
+	Query        string `json:"query"` // Lucene query
+	TimeField    string `json:"timeField"`
+	TimeEndField string `json:"timeEndField,omitempty"`
+	TextField    string `json:"textField,omitempty"`
+	TagsField    string `json:"tagsField,omitempty"`
+	TitleField   string `json:"titleField,omitempty"`
+
+	commonAnnotationOptions
+	raw rawObject // keeps fields of the query that aren't modelled
+}
+
+// NewElasticsearchAnnotation creates annotation query to Elasticsearch datasource.
+func NewElasticsearchAnnotation(name string, datasource DatasourceRef) *ElasticsearchAnnotation {
+	return &ElasticsearchAnnotation{
+		TimeField:               "@timestamp",
+		commonAnnotationOptions: newCommonAnnotationOptions(name, datasource),
+	}
+}
+
+func (a *ElasticsearchAnnotation) commonOptions() *commonAnnotationOptions {
+	return &a.commonAnnotationOptions
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (a *ElasticsearchAnnotation) MarshalJSON() ([]byte, error) {
+	type JSONAnnotation ElasticsearchAnnotation
+	return a.raw.encode((*JSONAnnotation)(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *ElasticsearchAnnotation) UnmarshalJSON(data []byte) error {
+	type JSONAnnotation ElasticsearchAnnotation
+	return a.raw.decode(data, (*JSONAnnotation)(a))
+}
+
+func newCommonAnnotationOptions(name string, datasource DatasourceRef) commonAnnotationOptions {
+	return commonAnnotationOptions{
+		Datasource: datasource,
+		Enable:     true,
+		IconColor:  defaultAnnotationIconColor,
+		Name:       name,
+	}
+}
+
+// probeAnnotation is an auxiliary entity thats purpose to manage marshaling and
+// unmarshaling of annotation queries into concrete types.
+type probeAnnotation struct {
+	annotation Annotation
+}
+
+// MarshalJSON implements json.Marshaler interface. Keys are sorted the same way
+// as Grafana does it when saves a dashboard.
+func (a *probeAnnotation) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(a.annotation)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *probeAnnotation) UnmarshalJSON(data []byte) error {
+	ja := struct {
+		BuiltIn    *uint8        `json:"builtIn"`
+		Datasource DatasourceRef `json:"datasource"`
+		Expr       *string       `json:"expr"`
+		Query      *string       `json:"query"`
+		TimeField  *string       `json:"timeField"`
+	}{}
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	// Datasources referred by name don't tell their type, so in this case some
+	// heuristics based on specific for annotation type fields is used.
+	ds := ja.Datasource
+	var annotation Annotation
+	switch {
+	case ja.BuiltIn != nil || ds.Name == builtInAnnotationsDatasource ||
+		ds.UID == builtInAnnotationsDatasource || ds.UID == builtInGrafanaDatasourceUID || ds.Type == "grafana":
+		annotation = new(BuiltInAnnotation)
+	case ds.Type == PrometheusDatasource:
+		annotation = new(PrometheusAnnotation)
+	case ds.Type == LokiDatasource:
+		annotation = new(LokiAnnotation)
+	case ds.Type == ElasticsearchDatasource:
+		annotation = new(ElasticsearchAnnotation)
+	case ds.Type != "":
+		annotation = new(RawAnnotation)
+	case ja.Expr != nil:
+		annotation = new(PrometheusAnnotation)
+	case ja.Query != nil && ja.TimeField != nil:
+		annotation = new(ElasticsearchAnnotation)
+	default:
+		// Annotations of unknown datasources are kept as they are
+		annotation = new(RawAnnotation)
+	}
+
+	if err := json.Unmarshal(data, annotation); err != nil {
+		return err
+	}
+
+	a.annotation = annotation
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func TestAnnotations_MarshalJSON(t *testing.T) {
+	builtIn := NewDashboardAnnotation("Annotations & Alerts")
+	builtIn.BuiltIn = 1
+	builtIn.Hide = true
+	builtIn.Datasource = DatasourceRef{UID: "-- Grafana --", Type: "grafana"}
+	builtIn.Target = &BuiltInAnnotationTarget{Limit: 100, Tags: []string{}, Type: DashboardAnnotationType}
+
+	tags := NewTagsAnnotation("Deploys", "deploy")
+	tags.MatchAny = true
+
+	prometheus := NewPrometheusAnnotation("Restarts", DatasourceRef{Name: "Prometheus"})
+	prometheus.Expr = `changes(process_start_time_seconds[1m]) > 0`
+	prometheus.Step = "60s"
+	prometheus.TitleFormat = "{{ job }} restarted"
+	prometheus.TagKeys = "job,instance"
+	prometheus.IconColor = "#F2495C"
+
+	loki := NewLokiAnnotation("Errors", DatasourceRef{UID: "loki", Type: LokiDatasource})
+	loki.Expr = `{app="api"} |= "error"`
+
+	es := NewElasticsearchAnnotation("Releases", DatasourceRef{Name: "ES"})
+	es.Query = "tags:release"
+	es.TextField = "message"
+	es.TagsField = "tags"
+
+	got, err := json.Marshal(Annotations{builtIn, tags, prometheus, loki, es})
+	if err != nil {
+		t.Fatalf("Annotations.MarshalJSON returned error %s", err)
+	}
+
+	// Grafana's API escapes HTML characters the same way as encoding/json does
+	expected := `{"list":[` +
+		`{"builtIn":1,"datasource":{"type":"grafana","uid":"-- Grafana --"},"enable":true,"hide":true,"iconColor":"rgba(0, 211, 255, 1)","limit":100,"name":"Annotations \u0026 Alerts","target":{"limit":100,"matchAny":false,"tags":[],"type":"dashboard"},"type":"dashboard"},` +
+		`{"datasource":"-- Grafana --","enable":true,"hide":false,"iconColor":"rgba(0, 211, 255, 1)","limit":100,"matchAny":true,"name":"Deploys","tags":["deploy"],"type":"tags"},` +
+		`{"datasource":"Prometheus","enable":true,"expr":"changes(process_start_time_seconds[1m]) \u003e 0","hide":false,"iconColor":"#F2495C","name":"Restarts","step":"60s","tagKeys":"job,instance","titleFormat":"{{ job }} restarted"},` +
+		`{"datasource":{"type":"loki","uid":"loki"},"enable":true,"expr":"{app=\"api\"} |= \"error\"","hide":false,"iconColor":"rgba(0, 211, 255, 1)","name":"Errors"},` +
+		`{"datasource":"ES","enable":true,"hide":false,"iconColor":"rgba(0, 211, 255, 1)","name":"Releases","query":"tags:release","tagsField":"tags","textField":"message","timeField":"@timestamp"}` +
+		`]}`
+	if string(got) != expected {
+		t.Errorf("Annotations.MarshalJSON:\ngot  %s\nwant %s", got, expected)
+	}
+}
+
+func TestAnnotations_UnmarshalJSON(t *testing.T) {
+	data := []byte(`{"list": [
+		{"builtIn": 1, "datasource": "-- Grafana --", "enable": true, "hide": true, "iconColor": "rgba(0, 211, 255, 1)", "name": "Annotations & Alerts", "type": "dashboard"},
+		{"datasource": "Prometheus", "enable": true, "expr": "up == 0", "hide": false, "iconColor": "red", "name": "Down", "step": "1m"},
+		{"datasource": {"type": "loki", "uid": "loki"}, "enable": false, "expr": "{app=\"api\"}", "hide": false, "iconColor": "red", "name": "Logs"},
+		{"datasource": "ES", "enable": true, "hide": false, "iconColor": "red", "name": "Releases", "query": "*", "timeField": "@timestamp"},
+		{"datasource": {"type": "datasource", "uid": "grafana"}, "enable": true, "iconColor": "rgba(0, 211, 255, 1)", "name": "Deploys", "type": "tags", "tags": ["deploy"]},
+		{"datasource": {"type": "influxdb", "uid": "influx"}, "enable": true, "name": "Unknown", "query": "SELECT * FROM events"}
+	]}`)
+
+	var got Annotations
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Annotations.UnmarshalJSON returned error %s", err)
+	}
+
+	builtIn := NewDashboardAnnotation("Annotations & Alerts")
+	builtIn.BuiltIn = 1
+	builtIn.Hide = true
+	builtIn.Limit = 0
+
+	prometheus := NewPrometheusAnnotation("Down", DatasourceRef{Name: "Prometheus"})
+	prometheus.Expr = "up == 0"
+	prometheus.Step = "1m"
+	prometheus.IconColor = "red"
+
+	loki := NewLokiAnnotation("Logs", DatasourceRef{UID: "loki", Type: LokiDatasource})
+	loki.Enable = false
+	loki.Expr = `{app="api"}`
+	loki.IconColor = "red"
+
+	es := NewElasticsearchAnnotation("Releases", DatasourceRef{Name: "ES"})
+	es.Query = "*"
+	es.IconColor = "red"
+
+	tags := NewTagsAnnotation("Deploys", "deploy")
+	tags.Datasource = DatasourceRef{UID: "grafana", Type: "datasource"}
+	tags.Limit = 0
+
+	if len(got) != 6 {
+		t.Fatalf("Annotations.UnmarshalJSON: got %d annotations, want 6", len(got))
+	}
+	expected := Annotations{builtIn, prometheus, loki, es, tags}
+	if typed := withoutRawFields(got[:5]); !reflect.DeepEqual(typed, expected) {
+		t.Errorf("Annotations.UnmarshalJSON: %s", pretty.Diff(typed, expected))
+	}
+
+	raw, ok := got[5].(*RawAnnotation)
+	if !ok || raw.Name != "Unknown" || raw.Datasource != (DatasourceRef{UID: "influx", Type: "influxdb"}) {
+		t.Fatalf("Annotations.UnmarshalJSON: got %#v, want raw annotation", got[5])
+	}
+	raw.Enable = false
+	data, err := json.Marshal(Annotations{raw})
+	if err != nil {
+		t.Fatalf("Annotations.MarshalJSON returned error %s", err)
+	}
+	expectedRaw := `{"list":[{"datasource":{"type":"influxdb","uid":"influx"},"enable":false,"name":"Unknown","query":"SELECT * FROM events"}]}`
+	if string(data) != expectedRaw {
+		t.Errorf("Annotations.MarshalJSON of raw annotation:\ngot  %s\nwant %s", data, expectedRaw)
+	}
+}
+
+func TestAnnotations_UnmodelledFields(t *testing.T) {
+	data := []byte(`{"list":[` +
+		`{"datasource":{"type":"prometheus","uid":"prom"},"enable":true,"expr":"up","filter":{"exclude":false,"ids":[2]},"hide":false,"iconColor":"red","name":"Down","target":{"expr":"up","refId":"Anno"}},` +
+		`{"builtIn":1,"datasource":{"type":"grafana","uid":"-- Grafana --"},"enable":true,"hide":true,"iconColor":"rgba(0, 211, 255, 1)","name":"Annotations \u0026 Alerts","showIn":0,"type":"dashboard"}` +
+		`]}`)
+
+	var got Annotations
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Annotations.UnmarshalJSON returned error %s", err)
+	}
+	prometheus, ok := got[0].(*PrometheusAnnotation)
+	if !ok {
+		t.Fatalf("Annotations.UnmarshalJSON: got %#v, want Prometheus annotation", got[0])
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("Annotations.MarshalJSON returned error %s", err)
+	}
+	if string(b) != string(data) {
+		t.Errorf("Annotations.MarshalJSON:\ngot  %s\nwant %s", b, data)
+	}
+
+	// Changed options are written over the unmodelled ones
+	prometheus.Expr = "up == 0"
+	prometheus.Step = "1m"
+	b, err = json.Marshal(Annotations{prometheus})
+	if err != nil {
+		t.Fatalf("Annotations.MarshalJSON returned error %s", err)
+	}
+	expected := `{"list":[{"datasource":{"type":"prometheus","uid":"prom"},"enable":true,"expr":"up == 0","filter":{"exclude":false,"ids":[2]},"hide":false,"iconColor":"red","name":"Down","step":"1m","target":{"expr":"up","refId":"Anno"}}]}`
+	if string(b) != expected {
+		t.Errorf("Annotations.MarshalJSON of changed annotation:\ngot  %s\nwant %s", b, expected)
+	}
+}
+
+// withoutRawFields returns copies of typed annotations without JSON they were
+// decoded from, so that they can be compared with ones created by constructors.
+func withoutRawFields(annotations Annotations) Annotations {
+	typed := make(Annotations, len(annotations))
+	for i, a := range annotations {
+		switch v := a.(type) {
+		case *BuiltInAnnotation:
+			c := *v
+			c.raw = rawObject{}
+			typed[i] = &c
+		case *PrometheusAnnotation:
+			c := *v
+			c.raw = rawObject{}
+			typed[i] = &c
+		case *LokiAnnotation:
+			c := *v
+			c.raw = rawObject{}
+			typed[i] = &c
+		case *ElasticsearchAnnotation:
+			c := *v
+			c.raw = rawObject{}
+			typed[i] = &c
+		default:
+			typed[i] = a
+		}
+	}
+	return typed
+}
@@ -0,0 +1,449 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/pkg/field"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
+// GridLayoutSchemaVersion is the first schema version of Grafana's dashboards
+// that uses grid layout (Dashboard.Panels) instead of rows.
+const GridLayoutSchemaVersion = 16
+
+// Parameters of legacy rows layout and the way how Grafana converts it to the grid.
+const (
+	defaultPanelSpan = 4
+	defaultRowHeight = 250 // px
+	gridCellHeight   = 30  // px
+	gridCellVMargin  = 8   // px
+	minPanelHeight   = gridCellHeight * 3
+)
+
+// UpgradeToGridLayout converts legacy Rows of dashboard into Panels placed on the
+// grid and raises SchemaVersion up to GridLayoutSchemaVersion.
+//
+// Panels of each row are placed from left to right and wrapped to a new line when
+// they don't fit into the grid's width. Rows that have a title, are collapsed or
+// repeated are converted into RowPanel.
+func (d *Dashboard) UpgradeToGridLayout() {
+	showRows := false
+	for _, row := range d.Rows {
+		if row.Collapsed || row.ShowTitle || row.RepeatFor != "" {
+			showRows = true
+			break
+		}
+	}
+
+	y := d.gridBottom()
+	for _, row := range d.Rows {
+		rowHeight := gridHeight(row.Height)
+
+		var rowPanel *RowPanel
+		if showRows {
+			rowPanel = NewRowPanel(row.Title)
+			rowPanel.Collapsed = row.Collapsed
+			rowPanel.RepeatFor = row.RepeatFor
+			rowPanel.GeneralOptions().GridPos = &panel.GridPos{X: 0, Y: y, W: panel.GridColumnCount, H: 1}
+			d.Panels = append(d.Panels, rowPanel)
+			y++
+		}
+
+		layout := legacyRowLayout{y: y, rowHeight: rowHeight}
+		for _, p := range row.Panels {
+			opts := p.GeneralOptions()
+			pos := layout.place(opts.Span, opts.Height)
+			opts.GridPos = &pos
+			opts.Span = 0
+			opts.Height = ""
+			opts.MinSpan = legacyMinSpan(opts.MinSpan)
+
+			if rowPanel != nil && rowPanel.Collapsed {
+				rowPanel.Panels = append(rowPanel.Panels, p)
+			} else {
+				d.Panels = append(d.Panels, p)
+			}
+		}
+
+		if rowPanel != nil && rowPanel.Collapsed {
+			// Panels of collapsed row don't take space on the grid.
+			continue
+		}
+		y = layout.bottom()
+	}
+
+	d.Rows = nil
+	if d.SchemaVersion < GridLayoutSchemaVersion {
+		d.SchemaVersion = GridLayoutSchemaVersion
+	}
+}
+
+// legacyRowLayout places panels of legacy row on the grid the same way as
+// Grafana does it.
+type legacyRowLayout struct {
+	x, y, lineHeight, rowHeight uint
+}
+
+// place returns position of the next panel with given legacy span and height.
+func (l *legacyRowLayout) place(span uint, height field.ForceString) panel.GridPos {
+	if span == 0 {
+		span = defaultPanelSpan
+	} else if span > panel.LegacyColumnCount {
+		span = panel.LegacyColumnCount
+	}
+	w := span * panel.GridColumnCount / panel.LegacyColumnCount
+
+	h := l.rowHeight
+	if height != "" {
+		h = gridHeight(height)
+	}
+
+	if l.x+w > panel.GridColumnCount {
+		l.y += l.lineHeight
+		l.x, l.lineHeight = 0, 0
+	}
+
+	pos := panel.GridPos{X: l.x, Y: l.y, W: w, H: h}
+	l.x += w
+	if h > l.lineHeight {
+		l.lineHeight = h
+	}
+	return pos
+}
+
+// bottom returns the first line below placed panels.
+func (l *legacyRowLayout) bottom() uint {
+	return l.y + l.lineHeight
+}
+
+// legacyMinSpan converts legacy minSpan into grid's units.
+func legacyMinSpan(minSpan uint) uint {
+	minSpan = minSpan * panel.GridColumnCount / panel.LegacyColumnCount
+	if minSpan > panel.GridColumnCount {
+		minSpan = panel.GridColumnCount
+	}
+	return minSpan
+}
+
+// gridHeight converts legacy height in pixels (ie. "250px") into grid's units.
+func gridHeight(height field.ForceString) uint {
+	px, err := strconv.Atoi(strings.TrimSuffix(string(height), "px"))
+	if err != nil || px <= 0 {
+		px = defaultRowHeight
+	}
+	if px < minPanelHeight {
+		px = minPanelHeight
+	}
+
+	step := gridCellHeight + gridCellVMargin
+	return uint((px + step - 1) / step)
+}
+
+// Default size of panel added by LayoutRow in grid's units.
+const (
+	defaultPanelWidth  = 12
+	defaultPanelHeight = 8
+)
+
+// LayoutRow places panels added to dashboard's row one after another from left
+// to right and wraps them to a new line when the line is full.
+//
+// By default each panel keeps its own width (GridPos.W or Span). Width can be
+// also computed by fixed number of columns (see Columns) or by weights (see Weights).
+//
+// Dashboards with legacy Rows get a new Row and panels get Span, the others get
+// RowPanel and panels are placed on the grid.
+type LayoutRow struct {
+	dashboard *Dashboard
+	legacyRow *Row
+
+	columns uint
+	weights []uint
+	height  uint
+
+	x, y, lineHeight, count uint
+}
+
+// AddRow adds new row with given title to the dashboard and returns LayoutRow to
+// place its panels. There is no row panel on the grid if title is empty.
+//
+// Panels of a row should be added before the next row is added, otherwise they
+// could overlap.
+func (d *Dashboard) AddRow(title string) *LayoutRow {
+	r := &LayoutRow{
+		dashboard: d,
+		height:    defaultPanelHeight,
+	}
+
+	if len(d.Rows) > 0 {
+		row := NewRow()
+		row.Title = title
+		row.ShowTitle = title != ""
+		row.Height = legacyHeight(r.height)
+		d.Rows = append(d.Rows, row)
+		r.legacyRow = row
+		return r
+	}
+
+	r.y = d.gridBottom()
+	if title != "" {
+		rowPanel := NewRowPanel(title)
+		rowPanel.GeneralOptions().GridPos = &panel.GridPos{X: 0, Y: r.y, W: panel.GridColumnCount, H: 1}
+		d.Panels = append(d.Panels, rowPanel)
+		r.y++
+	}
+	if d.SchemaVersion < GridLayoutSchemaVersion {
+		d.SchemaVersion = GridLayoutSchemaVersion
+	}
+
+	return r
+}
+
+// Columns makes each line of the row to have n panels of equal width.
+func (r *LayoutRow) Columns(n uint) *LayoutRow {
+	r.columns = n
+	r.weights = nil
+	return r
+}
+
+// Weights makes each line of the row to have len(weights) panels, width of each
+// panel is proportional to its weight.
+func (r *LayoutRow) Weights(weights ...uint) *LayoutRow {
+	r.weights = weights
+	r.columns = 0
+	return r
+}
+
+// Height sets height in grid's units of panels that don't have their own one.
+func (r *LayoutRow) Height(h uint) *LayoutRow {
+	r.height = h
+	if r.legacyRow != nil {
+		r.legacyRow.Height = legacyHeight(h)
+	}
+	return r
+}
+
+// Add places given panels into the row.
+func (r *LayoutRow) Add(panels ...Panel) *LayoutRow {
+	for _, p := range panels {
+		opts := p.GeneralOptions()
+
+		slots := r.slots()
+		slot := uint(0)
+		if slots > 0 {
+			slot = r.count % slots
+		}
+		w := r.width(opts, slot)
+
+		if r.x > 0 && (slots > 0 && slot == 0 || r.x+w > r.columnCount()) {
+			r.newLine()
+		}
+
+		if r.legacyRow != nil {
+			opts.Span = w
+			r.legacyRow.Panels = append(r.legacyRow.Panels, p)
+		} else {
+			h := r.height
+			if opts.GridPos != nil && opts.GridPos.H > 0 {
+				h = opts.GridPos.H
+			}
+			opts.GridPos = &panel.GridPos{X: r.x, Y: r.y, W: w, H: h}
+			r.dashboard.Panels = append(r.dashboard.Panels, p)
+			if h > r.lineHeight {
+				r.lineHeight = h
+			}
+		}
+
+		r.x += w
+		r.count++
+	}
+
+	return r
+}
+
+// columnCount returns width of the row in columns.
+func (r *LayoutRow) columnCount() uint {
+	if r.legacyRow != nil {
+		return panel.LegacyColumnCount
+	}
+	return panel.GridColumnCount
+}
+
+// slots returns a number of panels per line or 0 for flow layout.
+func (r *LayoutRow) slots() uint {
+	if len(r.weights) > 0 {
+		return uint(len(r.weights))
+	}
+	if r.columns > r.columnCount() {
+		return r.columnCount()
+	}
+	return r.columns
+}
+
+// width returns width of panel placed into given slot of the line.
+func (r *LayoutRow) width(opts *panel.GeneralOptions, slot uint) uint {
+	cols := r.columnCount()
+
+	switch {
+	case len(r.weights) > 0:
+		var total, before uint
+		for i, weight := range r.weights {
+			if weight == 0 {
+				weight = 1
+			}
+			if uint(i) < slot {
+				before += weight
+			}
+			total += weight
+		}
+		weight := r.weights[slot]
+		if weight == 0 {
+			weight = 1
+		}
+		// Width is computed from cumulative weights, so the line is always filled
+		// completely. Lines of more slots than columns wrap, panels are 1 column wide then.
+		if w := cols*(before+weight)/total - cols*before/total; w > 0 {
+			return w
+		}
+		return 1
+	case r.columns > 0:
+		n := r.slots()
+		w := cols / n
+		if slot < cols%n {
+			w++
+		}
+		return w
+	}
+
+	var w uint
+	if r.legacyRow != nil {
+		w = opts.Span
+		if w == 0 && opts.GridPos != nil {
+			w = opts.GridPos.W * panel.LegacyColumnCount / panel.GridColumnCount
+		}
+		if w == 0 {
+			w = defaultPanelWidth * panel.LegacyColumnCount / panel.GridColumnCount
+		}
+	} else {
+		if opts.GridPos != nil {
+			w = opts.GridPos.W
+		}
+		if w == 0 {
+			w = opts.Span * panel.GridColumnCount / panel.LegacyColumnCount
+		}
+		if w == 0 {
+			w = defaultPanelWidth
+		}
+	}
+	if w > cols {
+		w = cols
+	}
+	return w
+}
+
+// newLine moves placement to the beginning of a new line.
+func (r *LayoutRow) newLine() {
+	r.x = 0
+	if r.legacyRow == nil {
+		r.y += r.lineHeight
+		r.lineHeight = 0
+		return
+	}
+
+	// Legacy row can't be wider than 12 columns, so the line continues in a new row
+	row := NewRow()
+	row.Height = r.legacyRow.Height
+	rows := r.dashboard.Rows
+	for i, rr := range rows {
+		if rr == r.legacyRow {
+			rows = append(rows[:i+1], append([]*Row{row}, rows[i+1:]...)...)
+			break
+		}
+	}
+	r.dashboard.Rows = rows
+	r.legacyRow = row
+}
+
+// gridBottom returns the first free line of dashboard's grid.
+func (d *Dashboard) gridBottom() uint {
+	var y uint
+	for _, p := range d.Panels {
+		if pos := p.GeneralOptions().GridPos; pos != nil && pos.Y+pos.H > y {
+			y = pos.Y + pos.H
+		}
+	}
+	return y
+}
+
+// legacyHeight converts height in grid's units into legacy height in pixels.
+func legacyHeight(h uint) field.ForceString {
+	px := int(h)*(gridCellHeight+gridCellVMargin) - gridCellVMargin
+	return field.ForceString(strconv.Itoa(px) + "px")
+}
+
+// ValidateLayout checks that panels of dashboard don't overlap and fit into the
+// grid. It returns validate.Errors with JSON pointers to invalid positions, ie.
+// /panels/3/gridPos.
+func (d *Dashboard) ValidateLayout() error {
+	var errs validate.Errors
+	for i, row := range d.Rows {
+		for j, p := range row.Panels {
+			if span := p.GeneralOptions().Span; span > panel.LegacyColumnCount {
+				errs.Add(validate.Path("rows", i, "panels", j, "span"), "should be 1-%d, got %d", panel.LegacyColumnCount, span)
+			}
+		}
+	}
+
+	validateGrid(&errs, "/panels", d.Panels)
+	for i, p := range d.Panels {
+		if row, ok := p.(*RowPanel); ok && row.Collapsed {
+			validateGrid(&errs, validate.Path("panels", i, "panels"), row.Panels)
+		}
+	}
+
+	return errs.Err()
+}
+
+// validateGrid checks that given panels at path don't overlap and fit into the grid.
+func validateGrid(errs *validate.Errors, path string, panels Panels) {
+	for i, p := range panels {
+		posPath := path + validate.Path(i, "gridPos")
+		pos := p.GeneralOptions().GridPos
+		if pos == nil {
+			errs.Add(posPath, "should not be empty")
+			continue
+		}
+		if pos.W == 0 || pos.H == 0 {
+			errs.Add(posPath, "empty size %dx%d", pos.W, pos.H)
+		}
+		if pos.X+pos.W > panel.GridColumnCount {
+			errs.Add(posPath, "out of grid's bounds: x=%d, w=%d", pos.X, pos.W)
+		}
+
+		for j := 0; j < i; j++ {
+			other := panels[j].GeneralOptions().GridPos
+			if other == nil {
+				continue
+			}
+			if pos.X < other.X+other.W && other.X < pos.X+pos.W &&
+				pos.Y < other.Y+other.H && other.Y < pos.Y+pos.H {
+				errs.Add(posPath, "overlaps panel at %s", path+validate.Path(j))
+			}
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/pkg/field"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
+func TestDashboard_UpgradeToGridLayout(t *testing.T) {
+	newText := func(span uint, height string) *panel.Text {
+		p := panel.NewText(panel.TextPanelTextMode)
+		p.GeneralOptions().Span = span
+		p.GeneralOptions().Height = field.ForceString(height)
+		return p
+	}
+
+	t.Run("without titles", func(t *testing.T) {
+		d := NewDashboard("Dashboard")
+		row1 := NewRow()
+		row1.Height = "250px"
+		row1.Panels = Panels{newText(6, ""), newText(6, ""), newText(0, "")}
+		row2 := NewRow()
+		row2.Height = "250px"
+		row2.Panels = Panels{newText(12, "100px")}
+		d.Rows = []*Row{row1, row2}
+
+		d.UpgradeToGridLayout()
+
+		expected := []panel.GridPos{
+			{X: 0, Y: 0, W: 12, H: 7},
+			{X: 12, Y: 0, W: 12, H: 7},
+			{X: 0, Y: 7, W: 8, H: 7},
+			{X: 0, Y: 14, W: 24, H: 3},
+		}
+		if got := gridPositions(d.Panels); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Dashboard.UpgradeToGridLayout: %s", pretty.Diff(got, expected))
+		}
+		if d.Rows != nil {
+			t.Errorf("Dashboard.UpgradeToGridLayout: Rows should be nil, got %v", d.Rows)
+		}
+		if d.SchemaVersion != GridLayoutSchemaVersion {
+			t.Errorf("Dashboard.UpgradeToGridLayout: SchemaVersion %d, want %d", d.SchemaVersion, GridLayoutSchemaVersion)
+		}
+	})
+
+	t.Run("with collapsed row", func(t *testing.T) {
+		d := NewDashboard("Dashboard")
+		row1 := NewRow()
+		row1.ShowTitle = true
+		row1.Title = "Row 1"
+		row1.Panels = Panels{newText(12, "")}
+		row2 := NewRow()
+		row2.Collapsed = true
+		row2.Title = "Row 2"
+		row2.Panels = Panels{newText(6, "")}
+		row3 := NewRow()
+		row3.Title = "Row 3"
+		row3.Panels = Panels{newText(12, "")}
+		d.Rows = []*Row{row1, row2, row3}
+
+		d.UpgradeToGridLayout()
+
+		expected := []panel.GridPos{
+			{X: 0, Y: 0, W: 24, H: 1},
+			{X: 0, Y: 1, W: 24, H: 7},
+			{X: 0, Y: 8, W: 24, H: 1},
+			{X: 0, Y: 9, W: 24, H: 1},
+			{X: 0, Y: 10, W: 24, H: 7},
+		}
+		if got := gridPositions(d.Panels); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Dashboard.UpgradeToGridLayout: %s", pretty.Diff(got, expected))
+		}
+
+		collapsed, ok := d.Panels[2].(*RowPanel)
+		if !ok || !collapsed.Collapsed || collapsed.GeneralOptions().Title != "Row 2" {
+			t.Fatalf("Dashboard.UpgradeToGridLayout: expected collapsed row panel, got %+v", d.Panels[2])
+		}
+		expected = []panel.GridPos{{X: 0, Y: 9, W: 12, H: 7}}
+		if got := gridPositions(collapsed.Panels); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Dashboard.UpgradeToGridLayout: %s", pretty.Diff(got, expected))
+		}
+	})
+}
+
+func gridPositions(panels Panels) []panel.GridPos {
+	positions := make([]panel.GridPos, len(panels))
+	for i, p := range panels {
+		positions[i] = *p.GeneralOptions().GridPos
+	}
+	return positions
+}
+
+func TestRowPanel_UnmarshalJSON(t *testing.T) {
+	data := []byte(`[{
+		"id": 1,
+		"type": "row",
+		"title": "Row",
+		"collapsed": true,
+		"gridPos": {"x": 0, "y": 0, "w": 24, "h": 1},
+		"panels": [{
+			"id": 2,
+			"type": "text",
+			"mode": "text",
+			"content": "Content",
+			"gridPos": {"x": 0, "y": 1, "w": 12, "h": 8}
+		}]
+	}]`)
+	var got Panels
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Panels.UnmarshalJSON returned error %s", err)
+	}
+
+	child := panel.NewText(panel.TextPanelTextMode)
+	child.Content = "Content"
+	child.GeneralOptions().ID = 2
+	child.GeneralOptions().GridPos = &panel.GridPos{X: 0, Y: 1, W: 12, H: 8}
+	row := NewRowPanel("Row")
+	row.Collapsed = true
+	row.GeneralOptions().ID = 1
+	row.GeneralOptions().GridPos = &panel.GridPos{X: 0, Y: 0, W: 24, H: 1}
+	row.Panels = Panels{child}
+	expected := Panels{row}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Panels.UnmarshalJSON: %s", pretty.Diff(got, expected))
+	}
+}
+
+func TestLayoutRow_Add(t *testing.T) {
+	newPanels := func(n int) []Panel {
+		panels := make([]Panel, n)
+		for i := range panels {
+			panels[i] = panel.NewText(panel.TextPanelTextMode)
+		}
+		return panels
+	}
+
+	t.Run("flow", func(t *testing.T) {
+		d := NewDashboard("Dashboard")
+		wide := panel.NewText(panel.TextPanelTextMode)
+		wide.GeneralOptions().GridPos = &panel.GridPos{W: 18, H: 4}
+		d.AddRow("").Add(newPanels(2)...).Add(wide)
+		d.AddRow("Row").Height(6).Add(newPanels(1)...)
+
+		expected := []panel.GridPos{
+			{X: 0, Y: 0, W: 12, H: 8},
+			{X: 12, Y: 0, W: 12, H: 8},
+			{X: 0, Y: 8, W: 18, H: 4},
+			{X: 0, Y: 12, W: 24, H: 1},
+			{X: 0, Y: 13, W: 12, H: 6},
+		}
+		if got := gridPositions(d.Panels); !reflect.DeepEqual(got, expected) {
+			t.Errorf("LayoutRow.Add: %s", pretty.Diff(got, expected))
+		}
+		if d.SchemaVersion != GridLayoutSchemaVersion {
+			t.Errorf("LayoutRow.Add: SchemaVersion %d, want %d", d.SchemaVersion, GridLayoutSchemaVersion)
+		}
+		if err := d.ValidateLayout(); err != nil {
+			t.Errorf("Dashboard.ValidateLayout returned error %s", err)
+		}
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		d := NewDashboard("Dashboard")
+		d.AddRow("").Columns(5).Add(newPanels(6)...)
+
+		expected := []panel.GridPos{
+			{X: 0, Y: 0, W: 5, H: 8},
+			{X: 5, Y: 0, W: 5, H: 8},
+			{X: 10, Y: 0, W: 5, H: 8},
+			{X: 15, Y: 0, W: 5, H: 8},
+			{X: 20, Y: 0, W: 4, H: 8},
+			{X: 0, Y: 8, W: 5, H: 8},
+		}
+		if got := gridPositions(d.Panels); !reflect.DeepEqual(got, expected) {
+			t.Errorf("LayoutRow.Add: %s", pretty.Diff(got, expected))
+		}
+	})
+
+	t.Run("weights", func(t *testing.T) {
+		d := NewDashboard("Dashboard")
+		d.AddRow("").Weights(1, 2).Add(newPanels(3)...)
+
+		expected := []panel.GridPos{
+			{X: 0, Y: 0, W: 8, H: 8},
+			{X: 8, Y: 0, W: 16, H: 8},
+			{X: 0, Y: 8, W: 8, H: 8},
+		}
+		if got := gridPositions(d.Panels); !reflect.DeepEqual(got, expected) {
+			t.Errorf("LayoutRow.Add: %s", pretty.Diff(got, expected))
+		}
+	})
+
+	t.Run("more slots than columns", func(t *testing.T) {
+		weights := make([]uint, 30)
+		for i := range weights {
+			weights[i] = 1
+		}
+		for name, row := range map[string]func(*LayoutRow) *LayoutRow{
+			"columns": func(r *LayoutRow) *LayoutRow { return r.Columns(30) },
+			"weights": func(r *LayoutRow) *LayoutRow { return r.Weights(weights...) },
+		} {
+			d := NewDashboard("Dashboard")
+			row(d.AddRow("")).Add(newPanels(26)...)
+
+			for i, pos := range gridPositions(d.Panels) {
+				if pos.W == 0 {
+					t.Errorf("LayoutRow.Add (%s): panel %d has zero width", name, i)
+				}
+			}
+			if pos := d.Panels[25].GeneralOptions().GridPos; pos.Y != 8 {
+				t.Errorf("LayoutRow.Add (%s): the last panel is at line %d, want 8", name, pos.Y)
+			}
+			if err := d.ValidateLayout(); err != nil {
+				t.Errorf("Dashboard.ValidateLayout (%s) returned error %s", name, err)
+			}
+		}
+	})
+
+	t.Run("legacy rows", func(t *testing.T) {
+		d := NewDashboard("Dashboard")
+		d.Rows = []*Row{NewRow()}
+		d.AddRow("Row").Columns(3).Add(newPanels(4)...)
+
+		if len(d.Rows) != 3 {
+			t.Fatalf("LayoutRow.Add: expected 3 rows, got %d", len(d.Rows))
+		}
+		var spans [][]uint
+		for _, row := range d.Rows[1:] {
+			var rowSpans []uint
+			for _, p := range row.Panels {
+				rowSpans = append(rowSpans, p.GeneralOptions().Span)
+			}
+			spans = append(spans, rowSpans)
+		}
+		expected := [][]uint{{4, 4, 4}, {4}}
+		if !reflect.DeepEqual(spans, expected) {
+			t.Errorf("LayoutRow.Add: %s", pretty.Diff(spans, expected))
+		}
+		if d.Rows[1].Title != "Row" || d.Rows[2].ShowTitle {
+			t.Errorf("LayoutRow.Add: only the first row should have title, got %q and %q", d.Rows[1].Title, d.Rows[2].Title)
+		}
+	})
+}
+
+func TestDashboard_ValidateLayout(t *testing.T) {
+	ts := []struct {
+		name      string
+		positions []panel.GridPos
+		expected  []string
+	}{
+		{"valid", []panel.GridPos{{X: 0, Y: 0, W: 12, H: 8}, {X: 12, Y: 0, W: 12, H: 8}}, nil},
+		{"overlap", []panel.GridPos{{X: 0, Y: 0, W: 12, H: 8}, {X: 6, Y: 4, W: 12, H: 8}}, []string{"/panels/1/gridPos"}},
+		{"out of bounds", []panel.GridPos{{X: 20, Y: 0, W: 8, H: 8}}, []string{"/panels/0/gridPos"}},
+		{"empty size", []panel.GridPos{{X: 0, Y: 0, W: 0, H: 8}}, []string{"/panels/0/gridPos"}},
+		{
+			"all errors",
+			[]panel.GridPos{{X: 0, Y: 0, W: 12, H: 8}, {X: 6, Y: 4, W: 12, H: 8}, {X: 20, Y: 0, W: 8, H: 8}, {X: 0, Y: 0, W: 24, H: 1}},
+			[]string{"/panels/1/gridPos", "/panels/2/gridPos", "/panels/3/gridPos", "/panels/3/gridPos"},
+		},
+	}
+
+	for _, tt := range ts {
+		d := NewDashboard("Dashboard")
+		for i := range tt.positions {
+			p := panel.NewText(panel.TextPanelTextMode)
+			p.GeneralOptions().GridPos = &tt.positions[i]
+			d.Panels = append(d.Panels, p)
+		}
+
+		err := d.ValidateLayout()
+		if tt.expected == nil {
+			if err != nil {
+				t.Errorf("Dashboard.ValidateLayout (%s) returned error %s", tt.name, err)
+			}
+			continue
+		}
+		errs, ok := err.(validate.Errors)
+		if !ok {
+			t.Errorf("Dashboard.ValidateLayout (%s): expected validate.Errors, got %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, e := range errs {
+			got = append(got, e.Path)
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Dashboard.ValidateLayout (%s): got errors of %v, want %v\n%s", tt.name, got, tt.expected, errs)
+		}
+	}
+}
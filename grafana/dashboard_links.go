@@ -0,0 +1,85 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import "github.com/utilitywarehouse/go-grafana/pkg/validate"
+
+type dashboardLinkType string
+
+// Types of dashboard's links.
+const (
+	DashboardsLinkType dashboardLinkType = "dashboards"
+	LinkLinkType       dashboardLinkType = "link"
+)
+
+const defaultDashboardLinkIcon = "external link"
+
+// DashboardLink is a link shown at the top of a dashboard. It either points to
+// an arbitrary URL or to all dashboards that have given tags.
+type DashboardLink struct {
+	AsDropdown  bool              `json:"asDropdown"`
+	Icon        string            `json:"icon"` // ie. external link, dashboard, doc, info, question, bolt, cloud
+	IncludeVars bool              `json:"includeVars"`
+	KeepTime    bool              `json:"keepTime"`
+	Tags        []string          `json:"tags"`
+	TargetBlank bool              `json:"targetBlank"`
+	Title       string            `json:"title"`
+	Tooltip     string            `json:"tooltip"`
+	Type        dashboardLinkType `json:"type"`
+	URL         string            `json:"url"`
+}
+
+// NewDashboardsLink creates link to dashboards that have given tags.
+func NewDashboardsLink(title string, tags ...string) *DashboardLink {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return &DashboardLink{
+		Icon:  defaultDashboardLinkIcon,
+		Tags:  tags,
+		Title: title,
+		Type:  DashboardsLinkType,
+	}
+}
+
+// NewLink creates link to given URL.
+func NewLink(title, url string) *DashboardLink {
+	return &DashboardLink{
+		Icon:  defaultDashboardLinkIcon,
+		Tags:  []string{},
+		Title: title,
+		Type:  LinkLinkType,
+		URL:   url,
+	}
+}
+
+// Validate checks that link has a title and a target of its type.
+func (l *DashboardLink) Validate() error {
+	var errs validate.Errors
+	switch l.Type {
+	case DashboardsLinkType:
+	case LinkLinkType:
+		if l.Title == "" {
+			errs.Add("/title", "should not be empty")
+		}
+		if l.URL == "" {
+			errs.Add("/url", "should not be empty")
+		}
+	default:
+		errs.Add("/type", "should be %s or %s, got %q", DashboardsLinkType, LinkLinkType, l.Type)
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,182 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+)
+
+// rawObject keeps JSON object of an entity that isn't modelled by the package
+// completely, so that the entity is encoded back unchanged. Some options of the
+// entity are decoded from the object to be read and changed, changed ones are
+// written back over the object on encoding.
+type rawObject struct {
+	fields  map[string]json.RawMessage
+	decoded map[string]json.RawMessage // options as they were decoded
+}
+
+// decode decodes JSON object data and options of the entity from it.
+func (r *rawObject) decode(data []byte, opts interface{}) error {
+	if err := json.Unmarshal(data, &r.fields); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, opts); err != nil {
+		return err
+	}
+
+	var err error
+	r.decoded, err = marshalFields(opts)
+	return err
+}
+
+// encode returns JSON object with changed options written over it.
+func (r *rawObject) encode(opts interface{}) ([]byte, error) {
+	current, err := marshalFields(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage, len(r.fields))
+	for key, value := range r.fields {
+		fields[key] = value
+	}
+	for key, value := range current {
+		if !bytes.Equal(value, r.decoded[key]) {
+			fields[key] = value
+		}
+	}
+	// Options which became empty aren't encoded anymore
+	for key := range r.decoded {
+		if _, ok := current[key]; !ok {
+			delete(fields, key)
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// marshalFields encodes v into fields of JSON object.
+func marshalFields(v interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// RawPanel is a panel of a type that isn't modelled by the package, ie. stat,
+// timeseries or a plugin panel. It keeps JSON of the panel, so that the panel
+// survives fetching and saving of its dashboard. Only general options of the
+// panel are decoded, changes of them are saved.
+type RawPanel struct {
+	panelType      string
+	generalOptions panel.GeneralOptions
+	raw            rawObject
+}
+
+// Type returns type of the panel, ie. timeseries.
+func (p *RawPanel) Type() string {
+	return p.panelType
+}
+
+// GeneralOptions implements Panel interface
+func (p *RawPanel) GeneralOptions() *panel.GeneralOptions {
+	return &p.generalOptions
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (p *RawPanel) MarshalJSON() ([]byte, error) {
+	return p.raw.encode(&p.generalOptions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (p *RawPanel) UnmarshalJSON(data []byte) error {
+	jp := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+
+	p.panelType = jp.Type
+	return p.raw.decode(data, &p.generalOptions)
+}
+
+// RawAnnotation is an annotation query to a datasource that isn't modelled by
+// the package, ie. InfluxDB. It keeps JSON of the annotation query, so that it
+// survives fetching and saving of its dashboard. Only common options of the
+// query are decoded, changes of them are saved.
+type RawAnnotation struct {
+	commonAnnotationOptions
+	raw rawObject
+}
+
+func (a *RawAnnotation) commonOptions() *commonAnnotationOptions {
+	return &a.commonAnnotationOptions
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (a *RawAnnotation) MarshalJSON() ([]byte, error) {
+	return a.raw.encode(&a.commonAnnotationOptions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (a *RawAnnotation) UnmarshalJSON(data []byte) error {
+	return a.raw.decode(data, &a.commonAnnotationOptions)
+}
+
+// RawVariable is a variable of a type that isn't modelled by the package, ie.
+// textbox or adhoc. It keeps JSON of the variable, so that the variable survives
+// fetching and saving of its dashboard. Only common options of the variable are
+// decoded, changes of them are saved.
+type RawVariable struct {
+	commonVarOptions
+	varType string
+	raw     rawObject
+}
+
+// Type returns type of the variable, ie. textbox.
+func (v *RawVariable) Type() string {
+	return v.varType
+}
+
+func (v *RawVariable) commonOptions() *commonVarOptions {
+	return &v.commonVarOptions
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (v *RawVariable) MarshalJSON() ([]byte, error) {
+	return v.raw.encode(&v.commonVarOptions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (v *RawVariable) UnmarshalJSON(data []byte) error {
+	jv := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &jv); err != nil {
+		return err
+	}
+
+	v.varType = jv.Type
+	return v.raw.decode(data, &v.commonVarOptions)
+}
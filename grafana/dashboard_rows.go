@@ -0,0 +1,100 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"regexp"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/pkg/field"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
+// Row is a legacy (schemaVersion < 16) dashboard's row. Panels inside of it are
+// laid out by their Span and Height options.
+type Row struct {
+	Collapsed bool              `json:"collapse"`
+	Editable  bool              `json:"editable"`
+	Height    field.ForceString `json:"height"`
+	Panels    Panels            `json:"panels"`
+	RepeatFor string            `json:"repeat,omitempty"` // name of variable
+	ShowTitle bool              `json:"showTitle"`
+	Title     string            `json:"title"`
+	TitleSize string            `json:"titleSize"`
+}
+
+// NewRow creates new Row.
+func NewRow() *Row {
+	return &Row{
+		Editable: true,
+	}
+}
+
+var rowHeightRegexp = regexp.MustCompile(`^\d+(px)?$`)
+
+// Validate checks row's height and its panels.
+func (r *Row) Validate() error {
+	var errs validate.Errors
+	if r.Height != "" && !rowHeightRegexp.MatchString(string(r.Height)) {
+		errs.Add("/height", "should be in pixels, ie. 250px, got %q", r.Height)
+	}
+	validatePanels(&errs, "/panels", r.Panels)
+
+	return errs.Err()
+}
+
+// RowPanel is a panel of "row" type that groups panels of dashboards with grid
+// layout (schemaVersion 16+).
+//
+// Panels of expanded row follow it in Dashboard.Panels, only panels of collapsed
+// row are stored inside of it.
+type RowPanel struct {
+	Collapsed bool   `json:"collapsed"`
+	Panels    Panels `json:"panels"`
+	RepeatFor string `json:"repeat,omitempty"` // name of variable
+
+	generalOptions panel.GeneralOptions
+}
+
+// NewRowPanel creates new "Row" panel.
+func NewRowPanel(title string) *RowPanel {
+	return &RowPanel{
+		Panels: Panels{},
+		generalOptions: panel.GeneralOptions{
+			Title: title,
+		},
+	}
+}
+
+// GeneralOptions implements Panel interface
+func (p *RowPanel) GeneralOptions() *panel.GeneralOptions {
+	return &p.generalOptions
+}
+
+// Validate checks row's position and its panels.
+func (p *RowPanel) Validate() error {
+	var errs validate.Errors
+	errs.Merge("", p.generalOptions.Validate())
+	if !p.Collapsed && len(p.Panels) > 0 {
+		errs.Add("/panels", "should be empty for expanded row, its panels follow it on dashboard")
+	}
+	for i, child := range p.Panels {
+		if _, ok := child.(*RowPanel); ok {
+			errs.Add(validate.Path("panels", i), "rows can't be nested")
+		}
+	}
+	validatePanels(&errs, "/panels", p.Panels)
+
+	return errs.Err()
+}
@@ -20,7 +20,9 @@ import (
 
 	"github.com/kr/pretty"
 	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/grafana/query"
 	"github.com/utilitywarehouse/go-grafana/pkg/field"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 // JSONBytesEqual compares the JSON in two byte slices.
@@ -42,7 +44,7 @@ func TestDashboard_MarshalJSON(t *testing.T) {
 	d.HideControls = true
 	d.Style = dashboardLightStyle
 	d.Timezone = "MSK"
-	d.Tags = field.NewTags("tag1", "tag2")
+	d.Tags = []string{"tag1", "tag2"}
 
 	row1 := NewRow()
 	row1.Collapsed = true
@@ -81,19 +83,33 @@ func TestDashboard_MarshalJSON(t *testing.T) {
 	row2.Panels = []Panel{p2}
 
 	d.Rows = []*Row{row1, row2}
+	d.AssignPanelIDs()
 
 	got, err := json.MarshalIndent(&d, "", "\t")
 	if err != nil {
 		t.Fatalf("Dashboard.MarshalJSON returned error %s", err)
 	}
 	expected := []byte(`{
+		"annotations": {
+			"list": []
+		},
 		"schemaVersion": 14,
 		"editable": true,
 		"graphTooltip": 2,
 		"hideControls": true,
+		"links": null,
+		"refresh": false,
 		"templating": {
 			"list": []
 		},
+		"time": {
+			"from": "now-6h",
+			"to": "now"
+		},
+		"timepicker": {
+			"refresh_intervals": null,
+			"time_options": null
+		},
 		"rows": [{
 			"collapse": true,
 			"editable": true,
@@ -143,7 +159,9 @@ func TestDashboard_MarshalJSON(t *testing.T) {
 		"style": "light",
 		"timezone": "MSK",
 		"title": "Dashboard Title",
-		"tags": ["tag1", "tag2"]
+		"tags": ["tag1", "tag2"],
+		"uid": "",
+		"meta": null
 	}`)
 	if eq, err := JSONBytesEqual(expected, got); err != nil {
 		t.Fatalf("Dashboard.MarshalJSON returned error %s", err)
@@ -164,7 +182,14 @@ func TestDashboard_UnmarshalJSON(t *testing.T) {
 		"timezone": "msk",
 		"title": "Dashboard Title",
 		"tags": ["tag1", "tag2"],
-		"schemaVersion": 12
+		"schemaVersion": 12,
+		"links": [
+			{"asDropdown": true, "icon": "external link", "includeVars": true, "keepTime": true, "tags": ["tag1"], "targetBlank": false, "title": "Related", "tooltip": "", "type": "dashboards", "url": ""},
+			{"asDropdown": false, "icon": "doc", "includeVars": false, "keepTime": false, "tags": [], "targetBlank": true, "title": "Docs", "tooltip": "Read the docs", "type": "link", "url": "https://grafana.com/docs"}
+		],
+		"refresh": "30s",
+		"time": {"from": "now-6h", "to": "now"},
+		"timepicker": {"nowDelay": "1m", "refresh_intervals": ["30s", "1m"], "time_options": ["1h", "6h"]}
 	}`)
 	var got Dashboard
 	err := json.Unmarshal(data, &got)
@@ -173,15 +198,30 @@ func TestDashboard_UnmarshalJSON(t *testing.T) {
 	}
 
 	expected := NewDashboard("Dashboard Title")
-	expected.ID = 1
-	expected.SchemaVersion = 12
 	expected.Version = 2
+	expected.SchemaVersion = 12
 	expected.Editable = true
 	expected.GraphTooltip = 2
 	expected.HideControls = true
 	expected.Style = dashboardLightStyle
 	expected.Timezone = "msk"
-	expected.Tags = field.NewTags("tag1", "tag2")
+	expected.Tags = []string{"tag1", "tag2"}
+	related := NewDashboardsLink("Related", "tag1")
+	related.AsDropdown = true
+	related.IncludeVars = true
+	related.KeepTime = true
+	docs := NewLink("Docs", "https://grafana.com/docs")
+	docs.Icon = "doc"
+	docs.TargetBlank = true
+	docs.Tooltip = "Read the docs"
+	expected.Links = []*DashboardLink{related, docs}
+	expected.Refresh = "30s"
+	expected.Time = NewTimeRange("now-6h", "now")
+	expected.Timepicker = Timepicker{
+		NowDelay:         "1m",
+		RefreshIntervals: []string{"30s", "1m"},
+		TimeOptions:      []string{"1h", "6h"},
+	}
 
 	if !reflect.DeepEqual(&got, expected) {
 		t.Errorf("Dashboard.UnmarshalJSON: %s", pretty.Diff(&got, expected))
@@ -216,8 +256,9 @@ func TestProbePanel_UnmarshalJSON(t *testing.T) {
 	epxectedPanel := panel.NewText(panel.TextPanelMarkdownMode)
 	epxectedPanel.Content = "Content"
 	epxectedPanel.Mode = panel.TextPanelTextMode
-	expected := &probePanel{ID: 1, Type: textPanelType, panel: epxectedPanel}
+	expected := &probePanel{Type: textPanelType, panel: epxectedPanel}
 	opts := expected.GeneralOptions()
+	opts.ID = 1
 	opts.Description = "Panel Description"
 	opts.Height = "250px"
 	opts.MinSpan = 1
@@ -233,13 +274,14 @@ func TestProbePanel_MarshalJSON(t *testing.T) {
 	panel := panel.NewText(panel.TextPanelMarkdownMode)
 	panel.Content = "Content"
 	opts := panel.GeneralOptions()
+	opts.ID = 1
 	opts.Description = "Panel Description"
 	opts.Title = "Singlestat Panel"
 	opts.Height = "250px"
 	opts.MinSpan = 1
 	opts.Span = 12
 	opts.Transparent = true
-	pp := &probePanel{ID: 1, Type: textPanelType, panel: panel}
+	pp := &probePanel{Type: textPanelType, panel: panel}
 
 	got, err := json.MarshalIndent(pp, "", "\t\t")
 	if err != nil {
@@ -265,3 +307,229 @@ func TestProbePanel_MarshalJSON(t *testing.T) {
 		t.Errorf("probePanel.MarshalJSON: got %s, want %s\n", got, expected)
 	}
 }
+
+func TestDashboard_AssignPanelIDs(t *testing.T) {
+	newText := func(id uint) *panel.Text {
+		p := panel.NewText(panel.TextPanelTextMode)
+		p.GeneralOptions().ID = id
+		return p
+	}
+
+	d := NewDashboard("Dashboard")
+	row := NewRowPanel("Row")
+	row.Collapsed = true
+	row.Panels = Panels{newText(0), newText(5)}
+	d.Panels = Panels{newText(3), newText(0), row, newText(3)}
+	d.AssignPanelIDs()
+
+	var got []uint
+	for _, p := range d.allPanels() {
+		got = append(got, p.GeneralOptions().ID)
+	}
+	expected := []uint{3, 6, 7, 8, 5, 9}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Dashboard.AssignPanelIDs: got %v, want %v", got, expected)
+	}
+}
+
+func TestMakeRefID(t *testing.T) {
+	ts := []struct {
+		index    int
+		expected string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tt := range ts {
+		if got := makeRefID(tt.index); got != tt.expected {
+			t.Errorf("makeRefID(%d): got %s, want %s", tt.index, got, tt.expected)
+		}
+	}
+}
+
+func TestMakeRefIDs(t *testing.T) {
+	q1 := query.NewPrometheus("Prometheus")
+	q2 := query.NewPrometheus("Prometheus")
+	q2.SetRefID("A")
+	q3 := query.NewGraphite("Graphite")
+
+	got := makeRefIDs([]panel.Query{q1, q2, q3})
+	expected := []string{"B", "A", "C"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("makeRefIDs: got %v, want %v", got, expected)
+	}
+}
+
+func TestDashboard_Validate(t *testing.T) {
+	d := NewDashboard("Dashboard")
+	if err := d.Validate(); err != nil {
+		t.Fatalf("Dashboard.Validate returned error %s for new dashboard", err)
+	}
+
+	d.Title = ""
+	d.Refresh = "1 minute"
+	d.Time = NewTimeRange("now-6x", "now")
+	d.Links = []*DashboardLink{NewLink("Docs", "")}
+
+	v1 := NewQueryVar("var")
+	v1.Query = "label_values(job)"
+	v2 := NewConstantVariable("var")
+	v3 := NewConstantVariable("$var")
+	d.Templating = Variables{v1, v2, v3}
+
+	text := panel.NewText(panel.TextPanelTextMode)
+	text.GeneralOptions().ID = 1
+	graph := panel.NewGraph()
+	graph.GeneralOptions().ID = 1
+	graph.GeneralOptions().GridPos = &panel.GridPos{X: 20, Y: 0, W: 8, H: 8}
+	graph.YAxes.Left.LogBase = 3
+	*graph.Queries() = []panel.Query{query.NewPrometheus("Prometheus")}
+	row := NewRowPanel("Row")
+	row.Panels = Panels{panel.NewText(panel.TextPanelTextMode)}
+	d.Panels = Panels{text, graph, row}
+
+	err := d.Validate()
+	errs, ok := err.(validate.Errors)
+	if !ok {
+		t.Fatalf("Dashboard.Validate: expected validate.Errors, got %v", err)
+	}
+
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	expected := []string{
+		"/title",
+		"/refresh",
+		"/time/from",
+		"/links/0/url",
+		"/templating/list/1/name",
+		"/templating/list/2/name",
+		"/panels/1/gridPos/x",
+		"/panels/1/yaxes/0/logBase",
+		"/panels/1/targets/0/expr",
+		"/panels/2/panels",
+		"/panels/1/id",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Dashboard.Validate: got errors of %v, want %v\n%s", got, expected, err)
+	}
+}
+
+func TestPanels_UnknownTypes(t *testing.T) {
+	data := []byte(`[
+		{"id": 1, "type": "timeseries", "title": "Requests", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8},
+			"fieldConfig": {"defaults": {"unit": "reqps"}, "overrides": []},
+			"targets": [{"refId": "A", "expr": "sum(rate(http_requests_total[5m]))"}]},
+		{"id": 2, "type": "row", "title": "Details", "collapsed": true, "gridPos": {"x": 0, "y": 8, "w": 24, "h": 1},
+			"panels": [{"type": "grafana-piechart-panel", "title": "Status codes", "pieType": "donut"}]}
+	]`)
+
+	var panels Panels
+	if err := json.Unmarshal(data, &panels); err != nil {
+		t.Fatalf("Panels.UnmarshalJSON returned error %s", err)
+	}
+	if len(panels) != 2 {
+		t.Fatalf("Panels.UnmarshalJSON: got %d panels, want 2", len(panels))
+	}
+	timeseries, ok := panels[0].(*RawPanel)
+	if !ok || timeseries.Type() != "timeseries" || timeseries.GeneralOptions().Title != "Requests" {
+		t.Fatalf("Panels.UnmarshalJSON: got %#v, want raw timeseries panel", panels[0])
+	}
+
+	d := NewDashboard("Dashboard")
+	d.Panels = panels
+	d.AssignPanelIDs()
+	timeseries.GeneralOptions().GridPos.W = 24
+
+	got, err := json.Marshal(panels)
+	if err != nil {
+		t.Fatalf("Panels.MarshalJSON returned error %s", err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("Panels.MarshalJSON returned invalid JSON %s", err)
+	}
+	expected, _ := json.Marshal(map[string]interface{}{
+		"id": 1, "type": "timeseries", "title": "Requests", "gridPos": map[string]int{"x": 0, "y": 0, "w": 24, "h": 8},
+		"fieldConfig": map[string]interface{}{"defaults": map[string]string{"unit": "reqps"}, "overrides": []int{}},
+		"targets":     []map[string]string{{"refId": "A", "expr": "sum(rate(http_requests_total[5m]))"}},
+	})
+	if first, _ := json.Marshal(decoded[0]); string(first) != string(expected) {
+		t.Errorf("Panels.MarshalJSON: got %s, want %s", first, expected)
+	}
+	child := decoded[1]["panels"].([]interface{})[0].(map[string]interface{})
+	if child["type"] != "grafana-piechart-panel" || child["pieType"] != "donut" || child["id"] != float64(3) {
+		t.Errorf("Panels.MarshalJSON: got row's panel %v, want piechart panel with id 3", child)
+	}
+}
+
+func TestProbePanel_Datasources(t *testing.T) {
+	ts := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "names",
+			data:     `{"type": "graph", "datasource": "Prometheus", "targets": [{"refId": "A", "expr": "up", "intervalFactor": 1}]}`,
+			expected: `{"datasource": "Prometheus", "targets": [{}]}`,
+		},
+		{
+			name: "references",
+			data: `{"type": "graph", "datasource": {"type": "prometheus", "uid": "prom"}, "targets": [
+				{"refId": "A", "expr": "up", "intervalFactor": 1, "datasource": {"type": "prometheus", "uid": "prom"}},
+				{"refId": "B", "expr": "down", "intervalFactor": 1}
+			]}`,
+			expected: `{"datasource": {"type": "prometheus", "uid": "prom"}, "targets": [
+				{"datasource": {"type": "prometheus", "uid": "prom"}},
+				{"datasource": {"type": "prometheus", "uid": "prom"}}
+			]}`,
+		},
+		{
+			name: "mixed references",
+			data: `{"type": "graph", "datasource": {"type": "datasource", "uid": "-- Mixed --"}, "targets": [
+				{"refId": "A", "expr": "up", "intervalFactor": 1, "datasource": {"type": "prometheus", "uid": "prom"}},
+				{"refId": "B", "target": "a.b.c", "datasource": {"type": "graphite", "uid": "graphite"}}
+			]}`,
+			expected: `{"datasource": {"type": "datasource", "uid": "-- Mixed --"}, "targets": [
+				{"datasource": {"type": "prometheus", "uid": "prom"}},
+				{"datasource": {"type": "graphite", "uid": "graphite"}}
+			]}`,
+		},
+	}
+
+	for _, tt := range ts {
+		var pp probePanel
+		if err := json.Unmarshal([]byte(tt.data), &pp); err != nil {
+			t.Fatalf("probePanel.UnmarshalJSON (%s) returned error %s", tt.name, err)
+		}
+		data, err := json.Marshal(&pp)
+		if err != nil {
+			t.Fatalf("probePanel.MarshalJSON (%s) returned error %s", tt.name, err)
+		}
+
+		var jp struct {
+			Datasource json.RawMessage `json:"datasource,omitempty"`
+			Targets    []struct {
+				Datasource json.RawMessage `json:"datasource,omitempty"`
+			} `json:"targets"`
+		}
+		if err := json.Unmarshal(data, &jp); err != nil {
+			t.Fatalf("probePanel.MarshalJSON (%s) returned invalid JSON %s", tt.name, err)
+		}
+		got, _ := json.Marshal(jp)
+		if eq, err := JSONBytesEqual([]byte(tt.expected), got); err != nil {
+			t.Fatalf("probePanel.MarshalJSON (%s) returned error %s", tt.name, err)
+		} else if !eq {
+			t.Errorf("probePanel.MarshalJSON (%s): got %s, want %s", tt.name, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
+// Refresh is an auto-refresh interval of a dashboard, ie. 5s, 1m or 1h. Empty
+// value disables auto-refresh and is marshaled as false, the same way as
+// Grafana does it.
+type Refresh string
+
+// MarshalJSON implements json.Marshaler interface
+func (r Refresh) MarshalJSON() ([]byte, error) {
+	if r == "" {
+		return []byte("false"), nil
+	}
+	return json.Marshal(string(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (r *Refresh) UnmarshalJSON(data []byte) error {
+	var val interface{}
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	switch v := val.(type) {
+	case string:
+		*r = Refresh(v)
+	case bool, nil:
+		*r = ""
+	default:
+		return fmt.Errorf("invalid refresh %s", data)
+	}
+
+	return nil
+}
+
+// Duration returns refresh interval as time.Duration. Zero is returned when
+// auto-refresh is disabled.
+func (r Refresh) Duration() (time.Duration, error) {
+	if r == "" {
+		return 0, nil
+	}
+	return parseInterval(string(r))
+}
+
+// TimeRange is a time range of a dashboard. Each end is either relative to
+// current time, ie. now, now-6h or now-1d/d, or an absolute timestamp in
+// RFC 3339 format, ie. 2017-10-19T09:00:00.000Z.
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+const absoluteTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// NewTimeRange creates time range between given relative or absolute ends.
+func NewTimeRange(from, to string) TimeRange {
+	return TimeRange{From: from, To: to}
+}
+
+// NewAbsoluteTimeRange creates time range between given timestamps.
+func NewAbsoluteTimeRange(from, to time.Time) TimeRange {
+	return TimeRange{
+		From: from.UTC().Format(absoluteTimeFormat),
+		To:   to.UTC().Format(absoluteTimeFormat),
+	}
+}
+
+// Validate checks that both ends of the time range are valid and that the
+// range isn't empty when both ends are absolute.
+func (t TimeRange) Validate() error {
+	var errs validate.Errors
+	from, err := parseTimeRangeEnd(t.From)
+	if err != nil {
+		errs.Add("/from", "%s", err)
+	}
+	to, err := parseTimeRangeEnd(t.To)
+	if err != nil {
+		errs.Add("/to", "%s", err)
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		errs.Add("/to", "should be after from %q, got %q", t.From, t.To)
+	}
+
+	return errs.Err()
+}
+
+// relativeTimeRegexp matches Grafana's date math expressions.
+var relativeTimeRegexp = regexp.MustCompile(`^now(?:[+-]\d+[yMwdhms]|/(?:[yMwdhms]|fy|fQ))*$`)
+
+// parseTimeRangeEnd parses an end of time range. Zero time is returned for
+// relative ends.
+func parseTimeRangeEnd(s string) (time.Time, error) {
+	switch {
+	case s == "":
+		return time.Time{}, fmt.Errorf("should not be empty")
+	case relativeTimeRegexp.MatchString(s):
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	// Grafana also accepts Unix time in milliseconds
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%q is neither relative time nor timestamp", s)
+}
+
+// Timepicker is a settings of dashboard's time picker.
+type Timepicker struct {
+	Hidden           bool     `json:"hidden,omitempty"`
+	NowDelay         string   `json:"nowDelay,omitempty"` // ie. 1m to exclude the last minute of not yet collected data
+	RefreshIntervals []string `json:"refresh_intervals"`
+	TimeOptions      []string `json:"time_options"`
+}
+
+// Validate checks that all intervals of the time picker are valid.
+func (t Timepicker) Validate() error {
+	var errs validate.Errors
+	if t.NowDelay != "" {
+		if _, err := parseInterval(t.NowDelay); err != nil {
+			errs.Add("/nowDelay", "%s", err)
+		}
+	}
+	for i, interval := range t.RefreshIntervals {
+		if _, err := parseInterval(interval); err != nil {
+			errs.Add(validate.Path("refresh_intervals", i), "%s", err)
+		}
+	}
+	for i, interval := range t.TimeOptions {
+		if _, err := parseInterval(interval); err != nil {
+			errs.Add(validate.Path("time_options", i), "%s", err)
+		}
+	}
+
+	return errs.Err()
+}
+
+var intervalRegexp = regexp.MustCompile(`^(\d+)(ms|[yMwdhms])$`)
+
+var intervalUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	// Months and years have variable length, so they are approximated
+	"M": 30 * 24 * time.Hour,
+	"y": 365 * 24 * time.Hour,
+}
+
+// parseInterval parses Grafana's interval, ie. 30s, 5m or 1d.
+func parseInterval(s string) (time.Duration, error) {
+	m := intervalRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid interval %q", s)
+	}
+
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q: %s", s, err)
+	}
+
+	return time.Duration(n) * intervalUnits[m[2]], nil
+}
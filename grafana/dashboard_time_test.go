@@ -0,0 +1,125 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRefresh_JSON(t *testing.T) {
+	ts := []struct {
+		data     string
+		refresh  Refresh
+		expected string
+	}{
+		{`false`, "", `false`},
+		{`null`, "", `false`},
+		{`""`, "", `false`},
+		{`"5m"`, "5m", `"5m"`},
+	}
+
+	for _, tt := range ts {
+		var got Refresh
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Fatalf("Refresh.UnmarshalJSON (%s) returned error %s", tt.data, err)
+		}
+		if got != tt.refresh {
+			t.Errorf("Refresh.UnmarshalJSON (%s): got %q, want %q", tt.data, got, tt.refresh)
+		}
+
+		data, err := json.Marshal(got)
+		if err != nil {
+			t.Fatalf("Refresh.MarshalJSON (%q) returned error %s", got, err)
+		}
+		if string(data) != tt.expected {
+			t.Errorf("Refresh.MarshalJSON (%q): got %s, want %s", got, data, tt.expected)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`10`), new(Refresh)); err == nil {
+		t.Errorf("Refresh.UnmarshalJSON (10): expected error")
+	}
+}
+
+func TestRefresh_Duration(t *testing.T) {
+	ts := []struct {
+		refresh  Refresh
+		expected time.Duration
+		valid    bool
+	}{
+		{"", 0, true},
+		{"30s", 30 * time.Second, true},
+		{"5m", 5 * time.Minute, true},
+		{"1d", 24 * time.Hour, true},
+		{"5 minutes", 0, false},
+		{"m", 0, false},
+	}
+
+	for _, tt := range ts {
+		got, err := tt.refresh.Duration()
+		if (err == nil) != tt.valid {
+			t.Errorf("Refresh.Duration (%q): got error %v", tt.refresh, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Refresh.Duration (%q): got %s, want %s", tt.refresh, got, tt.expected)
+		}
+	}
+}
+
+func TestTimeRange_Validate(t *testing.T) {
+	ts := []struct {
+		name  string
+		tr    TimeRange
+		valid bool
+	}{
+		{"relative", NewTimeRange("now-6h", "now"), true},
+		{"rounded", NewTimeRange("now-1d/d", "now/d"), true},
+		{"fiscal year", NewTimeRange("now/fy", "now/fy"), true},
+		{"mixed", NewTimeRange("2017-10-19T09:00:00.000Z", "now"), true},
+		{"absolute", NewAbsoluteTimeRange(time.Unix(0, 0), time.Unix(3600, 0)), true},
+		{"milliseconds", NewTimeRange("1508403600000", "1508407200000"), true},
+		{"empty", NewTimeRange("", "now"), false},
+		{"invalid unit", NewTimeRange("now-6x", "now"), false},
+		{"garbage", NewTimeRange("yesterday", "now"), false},
+		{"reversed", NewAbsoluteTimeRange(time.Unix(3600, 0), time.Unix(0, 0)), false},
+	}
+
+	for _, tt := range ts {
+		if err := tt.tr.Validate(); (err == nil) != tt.valid {
+			t.Errorf("TimeRange.Validate (%s): got error %v", tt.name, err)
+		}
+	}
+}
+
+func TestTimepicker_Validate(t *testing.T) {
+	ts := []struct {
+		name  string
+		tp    Timepicker
+		valid bool
+	}{
+		{"empty", Timepicker{}, true},
+		{"valid", Timepicker{NowDelay: "1m", RefreshIntervals: []string{"5s", "1m"}, TimeOptions: []string{"5m", "7d"}}, true},
+		{"invalid now delay", Timepicker{NowDelay: "soon"}, false},
+		{"invalid refresh interval", Timepicker{RefreshIntervals: []string{"5s", "5"}}, false},
+		{"invalid time option", Timepicker{TimeOptions: []string{"now-1h"}}, false},
+	}
+
+	for _, tt := range ts {
+		if err := tt.tp.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Timepicker.Validate (%s): got error %v", tt.name, err)
+		}
+	}
+}
@@ -15,6 +15,10 @@ package grafana
 
 import (
 	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 type Variables []Variable
@@ -44,9 +48,12 @@ func (v *Variables) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	vars := make(Variables, len(jv.List))
-	for i, v := range jv.List {
-		vars[i] = v.variable
+	vars := make(Variables, 0, len(jv.List))
+	for _, pv := range jv.List {
+		if pv.variable == nil {
+			continue
+		}
+		vars = append(vars, pv.variable)
 	}
 	*v = vars
 
@@ -118,6 +125,8 @@ func (v *probeVariable) MarshalJSON() ([]byte, error) {
 			Type:             constantVarType,
 			ConstantVariable: vv,
 		}
+	case *RawVariable:
+		jj = vv
 	}
 
 	return json.Marshal(jj)
@@ -137,7 +146,6 @@ func (v *probeVariable) UnmarshalJSON(data []byte) error {
 
 	var vv Variable
 	switch jv.Type {
-	// TODO handle unknown type
 	case queryVarType:
 		vv = new(QueryVariable)
 	case intervalVarType:
@@ -148,6 +156,9 @@ func (v *probeVariable) UnmarshalJSON(data []byte) error {
 		vv = new(CustomVariable)
 	case constantVarType:
 		vv = new(ConstantVariable)
+	default:
+		// Variables of unknown types are kept as they are
+		vv = new(RawVariable)
 	}
 	if err := json.Unmarshal(data, vv); err != nil {
 		return err
@@ -164,12 +175,23 @@ type commonVarOptions struct {
 	Hide  hideType `json:"hide"`
 }
 
+var varNameRegexp = regexp.MustCompile(`^\w+$`)
+
+func (o *commonVarOptions) validate(errs *validate.Errors) {
+	if !varNameRegexp.MatchString(o.Name) {
+		errs.Add("/name", "should consist of letters, digits and underscores, got %q", o.Name)
+	}
+	if o.Hide > HideVariable {
+		errs.Add("/hide", "should be %d, %d or %d, got %d", NoHide, HideLabelOnly, HideVariable, o.Hide)
+	}
+}
+
 type hideType uint
 
 const (
 	NoHide        hideType = 0
 	HideLabelOnly hideType = 1
-	HideVariable           = 2
+	HideVariable  hideType = 2
 )
 
 // TODO: "refresh": 1, // it seems that On Dashboard Load / On Time Range change
@@ -202,6 +224,30 @@ func (v IntervalVariable) commonOptions() *commonVarOptions {
 	return &v.commonVarOptions
 }
 
+// Validate checks that variable's intervals are valid.
+func (v *IntervalVariable) Validate() error {
+	var errs validate.Errors
+	v.commonVarOptions.validate(&errs)
+	if v.Query == "" {
+		errs.Add("/query", "should not be empty")
+	}
+	for _, interval := range strings.Split(v.Query, ",") {
+		if interval = strings.TrimSpace(interval); interval == "" {
+			continue
+		}
+		if _, err := parseInterval(interval); err != nil {
+			errs.Add("/query", "%s", err)
+		}
+	}
+	if v.Auto {
+		if v.StepCount == 0 {
+			errs.Add("/auto_count", "should not be zero")
+		}
+	}
+
+	return errs.Err()
+}
+
 type sortType uint
 
 const (
@@ -213,13 +259,13 @@ const (
 )
 
 type QueryVariable struct {
-	Datasource string   `json:"datasource"`
-	IncludeAll bool     `json:"includeAll"`
-	Multi      bool     `json:"multi"`
-	Query      string   `json:"query"`
-	Regex      string   `json:"regex"`
-	Sort       sortType `json:"sort"`
-	AllValue   string   `json:"allValue"`
+	Datasource DatasourceRef `json:"datasource"`
+	IncludeAll bool          `json:"includeAll"`
+	Multi      bool          `json:"multi"`
+	Query      string        `json:"query"`
+	Regex      string        `json:"regex"`
+	Sort       sortType      `json:"sort"`
+	AllValue   string        `json:"allValue"`
 
 	/*
 		TODO: "refresh": 2, // it seems that On Dashboard Load / On Time Range change
@@ -239,6 +285,17 @@ func (v *QueryVariable) commonOptions() *commonVarOptions {
 	return &v.commonVarOptions
 }
 
+// Validate checks that variable has a query.
+func (v *QueryVariable) Validate() error {
+	var errs validate.Errors
+	v.commonVarOptions.validate(&errs)
+	if v.Query == "" {
+		errs.Add("/query", "should not be empty")
+	}
+
+	return errs.Err()
+}
+
 type DatasourceVariable struct {
 	Query string `json:"query"` // it's datasource name
 	Regex string `json:"regex"`
@@ -250,6 +307,17 @@ func (v DatasourceVariable) commonOptions() *commonVarOptions {
 	return &v.commonVarOptions
 }
 
+// Validate checks that variable has a type of datasources.
+func (v *DatasourceVariable) Validate() error {
+	var errs validate.Errors
+	v.commonVarOptions.validate(&errs)
+	if v.Query == "" {
+		errs.Add("/query", "should not be empty")
+	}
+
+	return errs.Err()
+}
+
 type CustomVariable struct {
 	IncludeAll bool   `json:"includeAll"`
 	AllValue   string `json:"allValue"`
@@ -263,6 +331,17 @@ func (v CustomVariable) commonOptions() *commonVarOptions {
 	return &v.commonVarOptions
 }
 
+// Validate checks that variable has values.
+func (v *CustomVariable) Validate() error {
+	var errs validate.Errors
+	v.commonVarOptions.validate(&errs)
+	if v.Query == "" {
+		errs.Add("/query", "should not be empty")
+	}
+
+	return errs.Err()
+}
+
 // ConstantVariable is a dashboard variable of Constant type.
 type ConstantVariable struct {
 	Value string `json:"query"`
@@ -281,3 +360,11 @@ func NewConstantVariable(name string) *ConstantVariable {
 func (v ConstantVariable) commonOptions() *commonVarOptions {
 	return &v.commonVarOptions
 }
+
+// Validate checks variable's name.
+func (v *ConstantVariable) Validate() error {
+	var errs validate.Errors
+	v.commonVarOptions.validate(&errs)
+
+	return errs.Err()
+}
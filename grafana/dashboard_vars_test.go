@@ -48,7 +48,7 @@ func TestVariables_UnmarshalJSON(t *testing.T) {
 	v.Query = `up{job="prometheus"}`
 	v.IncludeAll = true
 	v.Multi = true
-	v.Datasource = "Prometheus"
+	v.Datasource = DatasourceRef{Name: "Prometheus"}
 	v.AllValue = ".*"
 	v.Regex = "/local/"
 	v.Sort = NumericalDESC
@@ -66,7 +66,7 @@ func TestVariables_MarshalJSON(t *testing.T) {
 	v.Query = `up{job="prometheus"}`
 	v.IncludeAll = true
 	v.Multi = true
-	v.Datasource = "Prometheus"
+	v.Datasource = DatasourceRef{Name: "Prometheus"}
 	v.AllValue = ".*"
 	v.Regex = "/local/"
 	v.Sort = NumericalDESC
@@ -97,3 +97,66 @@ func TestVariables_MarshalJSON(t *testing.T) {
 		t.Errorf("probeVariable.MarshalJSON: got %s, want %s\n", got, expected)
 	}
 }
+
+func TestVariable_Validate(t *testing.T) {
+	interval := &IntervalVariable{
+		Auto:             true,
+		StepCount:        30,
+		Min:              10,
+		Query:            "1m, 10m,1h",
+		commonVarOptions: commonVarOptions{Name: "interval"},
+	}
+	custom := &CustomVariable{Query: "a,b", commonVarOptions: commonVarOptions{Name: "custom"}}
+
+	ts := []struct {
+		name     string
+		variable interface{ Validate() error }
+		valid    bool
+	}{
+		{"interval", interval, true},
+		{"invalid interval", &IntervalVariable{Query: "1m,1 hour", commonVarOptions: commonVarOptions{Name: "interval"}}, false},
+		{"auto without step count", &IntervalVariable{Auto: true, Query: "1m", commonVarOptions: commonVarOptions{Name: "interval"}}, false},
+		{"custom", custom, true},
+		{"empty custom", &CustomVariable{commonVarOptions: commonVarOptions{Name: "custom"}}, false},
+		{"query without query", NewQueryVar("query"), false},
+		{"constant with invalid name", NewConstantVariable("my var"), false},
+	}
+
+	for _, tt := range ts {
+		if err := tt.variable.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Variable.Validate (%s): got error %v", tt.name, err)
+		}
+	}
+}
+
+func TestVariables_UnknownTypes(t *testing.T) {
+	data := []byte(`{"list": [
+		{"name": "filter", "label": "", "hide": 0, "type": "textbox", "query": "ok", "current": {"text": "ok", "value": "ok"}},
+		{"name": "adhoc", "type": "adhoc", "datasource": {"type": "prometheus", "uid": "prom"}, "filters": []}
+	]}`)
+
+	var got Variables
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Variables.UnmarshalJSON returned error %s", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Variables.UnmarshalJSON: got %d variables, want 2", len(got))
+	}
+	textbox, ok := got[0].(*RawVariable)
+	if !ok || textbox.Type() != "textbox" || textbox.Name != "filter" {
+		t.Fatalf("Variables.UnmarshalJSON: got %#v, want raw textbox variable", got[0])
+	}
+
+	textbox.Label = "Filter"
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("Variables.MarshalJSON returned error %s", err)
+	}
+	expected := `{"list":[` +
+		`{"current":{"text":"ok","value":"ok"},"hide":0,"label":"Filter","name":"filter","query":"ok","type":"textbox"},` +
+		`{"datasource":{"type":"prometheus","uid":"prom"},"filters":[],"name":"adhoc","type":"adhoc"}` +
+		`]}`
+	if string(data) != expected {
+		t.Errorf("Variables.MarshalJSON:\ngot  %s\nwant %s", data, expected)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import "time"
+
+// DashboardVersion is a version of dashboard kept in its history. Every save of
+// a dashboard creates a new version.
+type DashboardVersion struct {
+	ID            uint64      `json:"id"`
+	DashboardID   DashboardID `json:"dashboardId"`
+	ParentVersion uint64      `json:"parentVersion"`
+	RestoredFrom  uint64      `json:"restoredFrom"` // version that this one was restored from, if any
+	Version       uint64      `json:"version"`
+	Created       time.Time   `json:"created"`
+	CreatedBy     string      `json:"createdBy"`
+	Message       string      `json:"message"`
+
+	// Dashboard is the dashboard as it was saved in this version. It's only
+	// fetched with a single version.
+	Dashboard *Dashboard `json:"data,omitempty"`
+}
@@ -13,7 +13,11 @@
 
 package grafana
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+)
 
 type (
 	// DatasourceID represents id type of datasource
@@ -29,12 +33,14 @@ const (
 )
 
 // datasourceType is type of datasource
-type datasourceType string
+type datasourceType = panel.DatasourceType
 
 // Types of datasource
 const (
-	GraphiteDatasource   datasourceType = "graphite"
-	PrometheusDatasource datasourceType = "prometheus"
+	GraphiteDatasource      datasourceType = "graphite"
+	PrometheusDatasource    datasourceType = "prometheus"
+	LokiDatasource          datasourceType = "loki"
+	ElasticsearchDatasource datasourceType = "elasticsearch"
 )
 
 // Datasource represents datasource entity of Grafana.
@@ -42,6 +48,7 @@ type Datasource struct {
 	id    DatasourceID
 	OrgID OrgID `json:"orgId"`
 
+	UID               string         `json:"uid,omitempty"`
 	Name              string         `json:"name"`
 	Type              datasourceType `json:"type"`
 	Access            httpAccessType `json:"access"`
@@ -92,3 +99,7 @@ func (d *Datasource) UnmarshalJSON(data []byte) error {
 
 	return json.Unmarshal(data, &jd)
 }
+
+// DatasourceRef refers a datasource from dashboard. Dashboards of schemaVersion < 33
+// refer datasources by name, the newer ones by uid and type.
+type DatasourceRef = panel.DatasourceRef
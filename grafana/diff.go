@@ -0,0 +1,299 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
+type changeType string
+
+// Types of changes between two dashboards.
+const (
+	AddedChange   changeType = "added"
+	RemovedChange changeType = "removed"
+	ChangedChange changeType = "changed"
+)
+
+// Change is a single difference between two dashboards. Path is a JSON pointer
+// to the value in the new dashboard, or in the old one if the value is removed.
+type Change struct {
+	Type changeType
+	Path string
+	Old  interface{} // is nil for added values
+	New  interface{} // is nil for removed values
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (c *Change) MarshalJSON() ([]byte, error) {
+	jc := struct {
+		Type changeType       `json:"type"`
+		Path string           `json:"path"`
+		Old  *json.RawMessage `json:"old,omitempty"`
+		New  *json.RawMessage `json:"new,omitempty"`
+	}{
+		Type: c.Type,
+		Path: c.Path,
+	}
+
+	if c.Type != AddedChange {
+		data, err := json.Marshal(c.Old)
+		if err != nil {
+			return nil, err
+		}
+		jc.Old = (*json.RawMessage)(&data)
+	}
+	if c.Type != RemovedChange {
+		data, err := json.Marshal(c.New)
+		if err != nil {
+			return nil, err
+		}
+		jc.New = (*json.RawMessage)(&data)
+	}
+
+	return json.Marshal(jc)
+}
+
+// DashboardDiff is a list of changes between two dashboards.
+type DashboardDiff struct {
+	Changes []*Change `json:"changes"`
+}
+
+// Empty reports whether dashboards are the same.
+func (d *DashboardDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders the diff as text, one change per line.
+func (d *DashboardDiff) String() string {
+	var b strings.Builder
+	for _, c := range d.Changes {
+		switch c.Type {
+		case AddedChange:
+			fmt.Fprintf(&b, "+ %s: %s\n", c.Path, formatDiffValue(c.New))
+		case RemovedChange:
+			fmt.Fprintf(&b, "- %s: %s\n", c.Path, formatDiffValue(c.Old))
+		case ChangedChange:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", c.Path, formatDiffValue(c.Old), formatDiffValue(c.New))
+		}
+	}
+	return b.String()
+}
+
+// ignoredDiffFields are fields of dashboard that change on every save.
+var ignoredDiffFields = []string{"id", "version", "meta"}
+
+// Diff compares dashboards a (old) and b (new) field by field. Panels are
+// matched by their IDs or, if they don't have one, by titles; variables and
+// annotations are matched by names. Other lists are compared by index.
+func Diff(a, b *Dashboard) (*DashboardDiff, error) {
+	oldDashboard, err := dashboardFields(a)
+	if err != nil {
+		return nil, err
+	}
+	newDashboard, err := dashboardFields(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DashboardDiff{Changes: []*Change{}}
+	d.diff("", oldDashboard, newDashboard)
+	return d, nil
+}
+
+// dashboardFields converts dashboard to generic JSON values without fields that
+// should be ignored by Diff.
+func dashboardFields(d *Dashboard) (map[string]interface{}, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, f := range ignoredDiffFields {
+		delete(fields, f)
+	}
+
+	return fields, nil
+}
+
+func (d *DashboardDiff) add(t changeType, path string, old, new interface{}) {
+	d.Changes = append(d.Changes, &Change{Type: t, Path: path, Old: old, New: new})
+}
+
+func (d *DashboardDiff) diff(path string, a, b interface{}) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			d.diffObjects(path, av, bv)
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			d.diffLists(path, av, bv)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		d.add(ChangedChange, path, a, b)
+	}
+}
+
+func (d *DashboardDiff) diffObjects(path string, a, b map[string]interface{}) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		av, inA := a[k]
+		bv, inB := b[k]
+		p := path + validate.Path(k)
+		switch {
+		case !inA:
+			d.add(AddedChange, p, nil, bv)
+		case !inB:
+			d.add(RemovedChange, p, av, nil)
+		default:
+			d.diff(p, av, bv)
+		}
+	}
+}
+
+// listKeys returns fields that identify elements of the list at given path.
+func listKeys(path string) []string {
+	switch {
+	case path == "/templating/list", path == "/annotations/list":
+		return []string{"name"}
+	case strings.HasSuffix(path, "/panels"):
+		return []string{"id", "title"}
+	}
+	return nil
+}
+
+func (d *DashboardDiff) diffLists(path string, a, b []interface{}) {
+	keys := listKeys(path)
+	if keys == nil {
+		for i := 0; i < len(a) || i < len(b); i++ {
+			p := path + validate.Path(i)
+			switch {
+			case i >= len(a):
+				d.add(AddedChange, p, nil, b[i])
+			case i >= len(b):
+				d.add(RemovedChange, p, a[i], nil)
+			default:
+				d.diff(p, a[i], b[i])
+			}
+		}
+		return
+	}
+
+	// match[j] is an index of element of a that matches j-th element of b
+	match := make([]int, len(b))
+	for j := range match {
+		match[j] = -1
+	}
+	matched := make([]bool, len(a))
+	for _, key := range keys {
+		unmatched := make(map[interface{}][]int)
+		for i, v := range a {
+			if k := listKey(v, key); k != nil && !matched[i] {
+				unmatched[k] = append(unmatched[k], i)
+			}
+		}
+		for j, v := range b {
+			k := listKey(v, key)
+			if k == nil || match[j] >= 0 || len(unmatched[k]) == 0 {
+				continue
+			}
+			i := unmatched[k][0]
+			unmatched[k] = unmatched[k][1:]
+			match[j] = i
+			matched[i] = true
+		}
+	}
+
+	for i, v := range a {
+		if !matched[i] {
+			d.add(RemovedChange, path+validate.Path(i), v, nil)
+		}
+	}
+	for j, v := range b {
+		p := path + validate.Path(j)
+		if match[j] < 0 {
+			d.add(AddedChange, p, nil, v)
+		} else {
+			d.diff(p, a[match[j]], v)
+		}
+	}
+}
+
+// listKey returns value of the key field of list's element or nil if the
+// element doesn't have one.
+func listKey(v interface{}, key string) interface{} {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	switch k := obj[key].(type) {
+	case string:
+		if k != "" {
+			return k
+		}
+	case float64:
+		if k != 0 {
+			return k
+		}
+	}
+	return nil
+}
+
+// formatDiffValue formats value for text rendering of diff. Panels, variables
+// and annotations are shortened to their types and titles or names.
+func formatDiffValue(v interface{}) string {
+	if obj, ok := v.(map[string]interface{}); ok {
+		title, _ := obj["title"].(string)
+		if title == "" {
+			title, _ = obj["name"].(string)
+		}
+		if t, _ := obj["type"].(string); t != "" && title != "" {
+			return fmt.Sprintf("%s %q", t, title)
+		}
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+)
+
+func TestDiff(t *testing.T) {
+	newText := func(id uint, title, content string) *panel.Text {
+		p := panel.NewText(panel.TextPanelMarkdownMode)
+		p.Content = content
+		p.GeneralOptions().ID = id
+		p.GeneralOptions().Title = title
+		return p
+	}
+	newDashboard := func() *Dashboard {
+		d := NewDashboard("Dashboard")
+		d.Templating = Variables{NewConstantVariable("env")}
+		d.Panels = Panels{
+			newText(1, "Notes", "notes"),
+			newText(2, "Help", "help"),
+			newText(0, "Untitled", ""),
+		}
+		return d
+	}
+
+	a := newDashboard()
+	a.ID = 1
+	a.Version = 3
+
+	t.Run("equal", func(t *testing.T) {
+		b := newDashboard()
+		b.ID = 2
+		b.Version = 4
+		b.Meta = &DashboardMeta{Slug: "dashboard"}
+
+		diff, err := Diff(a, b)
+		if err != nil {
+			t.Fatalf("Diff returned error %s", err)
+		}
+		if !diff.Empty() {
+			t.Errorf("Diff: expected no changes, got\n%s", diff)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		b := newDashboard()
+		b.Title = "New Title"
+		b.Refresh = "1m"
+		b.Templating = Variables{NewConstantVariable("region")}
+		// Panels are matched by ID regardless of their order and titles
+		b.Panels = Panels{
+			newText(2, "Help", "help"),
+			newText(1, "Notes & Todos", "notes"),
+			newText(0, "Untitled", "content"),
+			newText(0, "Graph", ""),
+		}
+		b.Panels[1].GeneralOptions().GridPos = &panel.GridPos{W: 24, H: 8}
+
+		diff, err := Diff(a, b)
+		if err != nil {
+			t.Fatalf("Diff returned error %s", err)
+		}
+
+		expected := `+ /panels/1/gridPos: {"h":8,"w":24,"x":0,"y":0}
+~ /panels/1/title: "Notes" -> "Notes & Todos"
+~ /panels/2/content: "" -> "content"
++ /panels/3: text "Graph"
+~ /refresh: false -> "1m"
+- /templating/list/0: constant "env"
++ /templating/list/0: constant "region"
+~ /title: "Dashboard" -> "New Title"
+`
+		if got := diff.String(); got != expected {
+			t.Errorf("Diff:\ngot\n%s\nwant\n%s", got, expected)
+		}
+
+		data, err := json.Marshal(diff)
+		if err != nil {
+			t.Fatalf("DashboardDiff.MarshalJSON returned error %s", err)
+		}
+		var changes struct {
+			Changes []map[string]interface{} `json:"changes"`
+		}
+		if err := json.Unmarshal(data, &changes); err != nil {
+			t.Fatalf("DashboardDiff.MarshalJSON returned invalid JSON %s", err)
+		}
+		if len(changes.Changes) != len(diff.Changes) {
+			t.Fatalf("DashboardDiff.MarshalJSON: got %d changes, want %d", len(changes.Changes), len(diff.Changes))
+		}
+		if _, ok := changes.Changes[3]["old"]; ok {
+			t.Errorf("DashboardDiff.MarshalJSON: added change shouldn't have old value, got %s", data)
+		}
+		if old, ok := changes.Changes[4]["old"]; !ok || old != false {
+			t.Errorf("DashboardDiff.MarshalJSON: changed change should have old value false, got %s", data)
+		}
+	})
+}
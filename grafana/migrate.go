@@ -0,0 +1,554 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/pkg/field"
+)
+
+// MigrateOptions specifies the optional parameters to Migrate.
+type MigrateOptions struct {
+	// GraphToTimeseries converts graph panels into timeseries ones. Grafana does
+	// it only when autoMigrateOldPanels feature is enabled.
+	GraphToTimeseries bool
+
+	// Datasources are used to convert datasource names into references. Names of
+	// unknown datasources are referenced by uid.
+	Datasources []*Datasource
+}
+
+// Schema versions of Grafana's migrations that Migrate reimplements.
+const (
+	singlestatToStatSchemaVersion      = 28
+	panelDatasourceRefSchemaVersion    = 33
+	variableDatasourceRefSchemaVersion = 36
+)
+
+// Names of special datasources
+const (
+	defaultDatasource           = "default"
+	builtInGrafanaDatasource    = "-- Grafana --"
+	builtInGrafanaDatasourceUID = "grafana"
+	builtInDatasourceType       = "datasource"
+)
+
+type migrationStep struct {
+	version int
+	migrate func(m *migrator, d map[string]interface{})
+}
+
+// migrationSteps are the steps of Grafana's DashboardMigrator that matter for
+// the model. Steps of all the other schema versions don't change anything.
+var migrationSteps = []migrationStep{
+	{GridLayoutSchemaVersion, (*migrator).upgradeToGridLayout},
+	{singlestatToStatSchemaVersion, (*migrator).upgradeSinglestats},
+	{panelDatasourceRefSchemaVersion, (*migrator).upgradePanelDatasources},
+	{variableDatasourceRefSchemaVersion, (*migrator).upgradeVariableDatasources},
+}
+
+// Migrate upgrades raw JSON of dashboard from its schemaVersion up to
+// targetVersion the same way as Grafana's frontend does it. Dashboard that
+// already has targetVersion or higher is not changed, except optional steps.
+func Migrate(data []byte, targetVersion int, opt *MigrateOptions) ([]byte, error) {
+	if opt == nil {
+		opt = &MigrateOptions{}
+	}
+
+	var d map[string]interface{}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+
+	version := rawInt(d["schemaVersion"])
+	if version >= targetVersion && !opt.GraphToTimeseries {
+		return data, nil
+	}
+
+	m := &migrator{opt: opt}
+	if version < targetVersion {
+		for _, step := range migrationSteps {
+			if version < step.version && step.version <= targetVersion {
+				step.migrate(m, d)
+			}
+		}
+		d["schemaVersion"] = targetVersion
+	}
+	if opt.GraphToTimeseries {
+		m.forEachPanel(d, func(p map[string]interface{}) {
+			if p["type"] == string(graphPanelType) {
+				graphToTimeseries(p)
+			}
+		})
+	}
+
+	return json.Marshal(d)
+}
+
+// migrator keeps state shared by migration steps.
+type migrator struct {
+	opt *MigrateOptions
+}
+
+// forEachPanel calls fn for every panel of the dashboard including panels of rows.
+func (m *migrator) forEachPanel(d map[string]interface{}, fn func(p map[string]interface{})) {
+	var walk func(panels []interface{})
+	walk = func(panels []interface{}) {
+		for _, v := range panels {
+			p, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			fn(p)
+			if children, ok := p["panels"].([]interface{}); ok {
+				walk(children)
+			}
+		}
+	}
+
+	if panels, ok := d["panels"].([]interface{}); ok {
+		walk(panels)
+	}
+	if rows, ok := d["rows"].([]interface{}); ok {
+		for _, v := range rows {
+			if row, ok := v.(map[string]interface{}); ok {
+				if panels, ok := row["panels"].([]interface{}); ok {
+					walk(panels)
+				}
+			}
+		}
+	}
+}
+
+// upgradeToGridLayout converts rows into panels placed on the grid (schemaVersion 16).
+func (m *migrator) upgradeToGridLayout(d map[string]interface{}) {
+	rows, ok := d["rows"].([]interface{})
+	if !ok {
+		return
+	}
+	delete(d, "rows")
+
+	var nextID int
+	m.forEachPanel(map[string]interface{}{"rows": rows}, func(p map[string]interface{}) {
+		if id := rawInt(p["id"]); id > nextID {
+			nextID = id
+		}
+	})
+	nextID++
+
+	showRows := false
+	for _, v := range rows {
+		row, _ := v.(map[string]interface{})
+		if rawBool(row["collapse"]) || rawBool(row["showTitle"]) || rawString(row["repeat"]) != "" {
+			showRows = true
+			break
+		}
+	}
+
+	panels := []interface{}{}
+	var y uint
+	for _, v := range rows {
+		row, ok := v.(map[string]interface{})
+		if !ok || row["repeatIteration"] != nil {
+			continue
+		}
+		rowHeight := gridHeight(rawHeight(row["height"]))
+		collapsed := rawBool(row["collapse"])
+
+		var rowPanel map[string]interface{}
+		if showRows {
+			rowPanel = map[string]interface{}{
+				"id":        nextID,
+				"type":      string(rowPanelType),
+				"title":     row["title"],
+				"collapsed": collapsed,
+				"repeat":    row["repeat"],
+				"panels":    []interface{}{},
+				"gridPos":   panel.GridPos{X: 0, Y: y, W: panel.GridColumnCount, H: 1},
+			}
+			panels = append(panels, rowPanel)
+			nextID++
+			y++
+		}
+
+		layout := legacyRowLayout{y: y, rowHeight: rowHeight}
+		rowPanels, _ := row["panels"].([]interface{})
+		for _, pv := range rowPanels {
+			p, ok := pv.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			p["gridPos"] = layout.place(uint(rawInt(p["span"])), rawHeight(p["height"]))
+			if minSpan := rawInt(p["minSpan"]); minSpan > 0 {
+				p["minSpan"] = legacyMinSpan(uint(minSpan))
+			}
+			delete(p, "span")
+			delete(p, "height")
+
+			if rowPanel != nil && collapsed {
+				rowPanel["panels"] = append(rowPanel["panels"].([]interface{}), p)
+			} else {
+				panels = append(panels, p)
+			}
+		}
+
+		if rowPanel == nil || !collapsed {
+			y = layout.bottom()
+		}
+	}
+
+	d["panels"] = panels
+}
+
+// upgradeSinglestats converts singlestat panels into stat or gauge ones (schemaVersion 28).
+func (m *migrator) upgradeSinglestats(d map[string]interface{}) {
+	m.forEachPanel(d, func(p map[string]interface{}) {
+		if p["type"] == string(singlestatPanelType) {
+			singlestatToStat(p)
+		}
+	})
+}
+
+// upgradePanelDatasources converts datasource names of panels and their queries
+// into references (schemaVersion 33).
+func (m *migrator) upgradePanelDatasources(d map[string]interface{}) {
+	m.forEachPanel(d, func(p map[string]interface{}) {
+		p["datasource"] = m.datasourceRef(p["datasource"])
+
+		targets, _ := p["targets"].([]interface{})
+		for _, tv := range targets {
+			if t, ok := tv.(map[string]interface{}); ok {
+				if ref := m.datasourceRef(t["datasource"]); ref != nil {
+					t["datasource"] = ref
+				}
+			}
+		}
+	})
+}
+
+// upgradeVariableDatasources converts datasource names of query variables and
+// annotations into references (schemaVersion 36).
+func (m *migrator) upgradeVariableDatasources(d map[string]interface{}) {
+	for _, key := range []string{"templating", "annotations"} {
+		section, _ := d[key].(map[string]interface{})
+		list, _ := section["list"].([]interface{})
+		for _, v := range list {
+			item, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if key == "templating" && item["type"] != string(queryVarType) {
+				continue
+			}
+			if ref := m.datasourceRef(item["datasource"]); ref != nil {
+				item["datasource"] = ref
+			}
+		}
+	}
+}
+
+// datasourceRef converts datasource name into reference. It returns nil for
+// default datasource.
+func (m *migrator) datasourceRef(nameOrRef interface{}) interface{} {
+	name, ok := nameOrRef.(string)
+	if !ok {
+		// It's either null or already a reference
+		return nameOrRef
+	}
+
+	switch name {
+	case "", defaultDatasource:
+		return nil
+	case mixedDatasource:
+		return map[string]interface{}{"type": builtInDatasourceType, "uid": mixedDatasource}
+	case builtInGrafanaDatasource:
+		return map[string]interface{}{"type": builtInDatasourceType, "uid": builtInGrafanaDatasourceUID}
+	}
+
+	for _, ds := range m.opt.Datasources {
+		if ds.Name == name || ds.UID == name {
+			return map[string]interface{}{"type": string(ds.Type), "uid": ds.UID}
+		}
+	}
+	return map[string]interface{}{"uid": name}
+}
+
+// singlestatToStat converts options of singlestat panel into stat or gauge panel ones.
+func singlestatToStat(p map[string]interface{}) {
+	defaults := map[string]interface{}{}
+	if format := rawString(p["format"]); format != "" {
+		defaults["unit"] = format
+	}
+	if p["decimals"] != nil {
+		defaults["decimals"] = p["decimals"]
+	}
+
+	colors, _ := p["colors"].([]interface{})
+	color := func(i int) interface{} {
+		if i < len(colors) {
+			return colors[i]
+		}
+		return "red"
+	}
+	steps := []interface{}{map[string]interface{}{"color": color(0), "value": nil}}
+	for i, v := range strings.Split(rawString(p["thresholds"]), ",") {
+		value, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			continue
+		}
+		steps = append(steps, map[string]interface{}{"color": color(i + 1), "value": value})
+	}
+	defaults["thresholds"] = map[string]interface{}{"mode": "absolute", "steps": steps}
+
+	mappings := []interface{}{}
+	switch rawInt(p["mappingType"]) {
+	case int(panel.RangeToTextType):
+		rangeMaps, _ := p["rangeMaps"].([]interface{})
+		for _, v := range rangeMaps {
+			rm, _ := v.(map[string]interface{})
+			mappings = append(mappings, map[string]interface{}{
+				"type": "range",
+				"options": map[string]interface{}{
+					"from":   rawFloat(rm["from"]),
+					"to":     rawFloat(rm["to"]),
+					"result": map[string]interface{}{"text": rm["text"]},
+				},
+			})
+		}
+	default:
+		valueMaps, _ := p["valueMaps"].([]interface{})
+		for _, v := range valueMaps {
+			vm, _ := v.(map[string]interface{})
+			mappings = append(mappings, map[string]interface{}{
+				"type": "value",
+				"options": map[string]interface{}{
+					rawString(vm["value"]): map[string]interface{}{"text": vm["text"]},
+				},
+			})
+		}
+	}
+	defaults["mappings"] = mappings
+
+	colorMode := "none"
+	if rawBool(p["colorBackground"]) {
+		colorMode = "background"
+	} else if rawBool(p["colorValue"]) {
+		colorMode = "value"
+	}
+	graphMode := "none"
+	if sparkline, ok := p["sparkline"].(map[string]interface{}); ok && rawBool(sparkline["show"]) {
+		graphMode = "area"
+	}
+
+	options := map[string]interface{}{
+		"reduceOptions": map[string]interface{}{
+			"calcs":  []interface{}{reducerOf(rawString(p["valueName"]))},
+			"fields": "",
+			"values": false,
+		},
+		"orientation": "horizontal",
+		"textMode":    "auto",
+		"justifyMode": "auto",
+		"colorMode":   colorMode,
+		"graphMode":   graphMode,
+	}
+
+	p["type"] = "stat"
+	if gauge, ok := p["gauge"].(map[string]interface{}); ok && rawBool(gauge["show"]) {
+		p["type"] = "gauge"
+		defaults["min"] = gauge["minValue"]
+		defaults["max"] = gauge["maxValue"]
+		options = map[string]interface{}{
+			"reduceOptions":        options["reduceOptions"],
+			"showThresholdLabels":  rawBool(gauge["thresholdLabels"]),
+			"showThresholdMarkers": rawBool(gauge["thresholdMarkers"]),
+		}
+	}
+	p["fieldConfig"] = map[string]interface{}{"defaults": defaults, "overrides": []interface{}{}}
+	p["options"] = options
+
+	for _, key := range []string{
+		"valueName", "valueFontSize", "postfix", "postfixFontSize", "prefix", "prefixFontSize",
+		"format", "decimals", "colorBackground", "colorValue", "thresholds", "colors",
+		"sparkline", "gauge", "mappingType", "valueMaps", "rangeMaps", "nullPointMode", "nullText",
+	} {
+		delete(p, key)
+	}
+}
+
+// graphToTimeseries converts basic options of graph panel into timeseries panel ones.
+// Series overrides, right Y axis and thresholds are dropped.
+func graphToTimeseries(p map[string]interface{}) {
+	custom := map[string]interface{}{
+		"drawStyle":         "line",
+		"lineInterpolation": "linear",
+		"lineWidth":         rawInt(p["linewidth"]),
+		"fillOpacity":       rawInt(p["fill"]) * 10,
+		"pointSize":         rawInt(p["pointradius"]) * 2,
+		"showPoints":        "never",
+		"spanNulls":         p["nullPointMode"] == string(panel.ConnectedNullPointMode),
+		"stacking":          map[string]interface{}{"mode": "none", "group": "A"},
+	}
+	if rawBool(p["bars"]) {
+		custom["drawStyle"] = "bars"
+	} else if !rawBool(p["lines"]) && rawBool(p["points"]) {
+		custom["drawStyle"] = "points"
+	}
+	if rawBool(p["points"]) {
+		custom["showPoints"] = "always"
+	}
+	if rawBool(p["steppedLine"]) {
+		custom["lineInterpolation"] = "stepAfter"
+	}
+	if rawBool(p["stack"]) {
+		mode := "normal"
+		if rawBool(p["percentage"]) {
+			mode = "percent"
+		}
+		custom["stacking"] = map[string]interface{}{"mode": mode, "group": "A"}
+	}
+
+	defaults := map[string]interface{}{"custom": custom}
+	if p["decimals"] != nil {
+		defaults["decimals"] = p["decimals"]
+	}
+	if yaxes, ok := p["yaxes"].([]interface{}); ok && len(yaxes) > 0 {
+		if axis, ok := yaxes[0].(map[string]interface{}); ok {
+			if format := rawString(axis["format"]); format != "" {
+				defaults["unit"] = format
+			}
+			if v := rawFloat(axis["min"]); v != nil {
+				defaults["min"] = v
+			}
+			if v := rawFloat(axis["max"]); v != nil {
+				defaults["max"] = v
+			}
+			if label := rawString(axis["label"]); label != "" {
+				custom["axisLabel"] = label
+			}
+			if logBase := rawInt(axis["logBase"]); logBase > 1 {
+				custom["scaleDistribution"] = map[string]interface{}{"type": "log", "log": logBase}
+			}
+		}
+	}
+
+	legendOpts := map[string]interface{}{
+		"showLegend":  true,
+		"displayMode": "list",
+		"placement":   "bottom",
+		"calcs":       []interface{}{},
+	}
+	if legend, ok := p["legend"].(map[string]interface{}); ok {
+		legendOpts["showLegend"] = rawBool(legend["show"])
+		if rawBool(legend["alignAsTable"]) {
+			legendOpts["displayMode"] = "table"
+		}
+		if rawBool(legend["rightSide"]) {
+			legendOpts["placement"] = "right"
+		}
+		if rawBool(legend["values"]) {
+			calcs := []interface{}{}
+			for _, name := range []string{"min", "max", "avg", "current", "total"} {
+				if rawBool(legend[name]) {
+					calcs = append(calcs, reducerOf(name))
+				}
+			}
+			legendOpts["calcs"] = calcs
+		}
+	}
+	tooltipMode := "single"
+	if tooltip, ok := p["tooltip"].(map[string]interface{}); ok && rawBool(tooltip["shared"]) {
+		tooltipMode = "multi"
+	}
+
+	p["type"] = "timeseries"
+	p["fieldConfig"] = map[string]interface{}{"defaults": defaults, "overrides": []interface{}{}}
+	p["options"] = map[string]interface{}{
+		"legend":  legendOpts,
+		"tooltip": map[string]interface{}{"mode": tooltipMode},
+	}
+
+	for _, key := range []string{
+		"aliasColors", "bars", "dashLength", "dashes", "decimals", "fill", "fillGradient", "hiddenSeries",
+		"legend", "lines", "linewidth", "nullPointMode", "percentage", "pointradius", "points", "renderer",
+		"seriesOverrides", "spaceLength", "stack", "steppedLine", "thresholds", "timeRegions", "tooltip",
+		"xaxis", "yaxes", "yaxis",
+	} {
+		delete(p, key)
+	}
+}
+
+// reducerOf returns name of Grafana's reducer for legacy value name.
+func reducerOf(valueName string) string {
+	switch valueName {
+	case "current":
+		return "lastNotNull"
+	case "total":
+		return "sum"
+	case "first":
+		return "firstNotNull"
+	case "min", "max", "delta", "diff", "range":
+		return valueName
+	case "name":
+		return "last"
+	default:
+		return "mean"
+	}
+}
+
+func rawInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+	return 0
+}
+
+func rawFloat(v interface{}) interface{} {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case string:
+		if f, err := strconv.ParseFloat(n, 64); err == nil {
+			return f
+		}
+	}
+	return nil
+}
+
+func rawBool(v interface{}) bool {
+	b, _ := v.(bool)
+	return b
+}
+
+func rawString(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	}
+	return ""
+}
+
+func rawHeight(v interface{}) field.ForceString {
+	return field.ForceString(rawString(v))
+}
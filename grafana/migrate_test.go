@@ -0,0 +1,273 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	ts := []struct {
+		name          string
+		data          string
+		targetVersion int
+		opt           *MigrateOptions
+		expected      string
+	}{
+		{
+			name:          "up to date",
+			data:          `{"schemaVersion": 16, "rows": []}`,
+			targetVersion: 16,
+			expected:      `{"schemaVersion": 16, "rows": []}`,
+		},
+		{
+			name: "rows to panels",
+			data: `{
+				"schemaVersion": 14,
+				"rows": [{
+					"collapse": false,
+					"height": "250px",
+					"showTitle": true,
+					"title": "Row 1",
+					"panels": [
+						{"id": 1, "type": "text", "span": 6},
+						{"id": 2, "type": "text", "span": 6, "height": "100px"}
+					]
+				}, {
+					"collapse": true,
+					"height": 250,
+					"title": "Row 2",
+					"panels": [{"id": 3, "type": "text", "span": 12, "minSpan": 2}]
+				}]
+			}`,
+			targetVersion: 16,
+			expected: `{
+				"schemaVersion": 16,
+				"panels": [
+					{"id": 4, "type": "row", "title": "Row 1", "collapsed": false, "repeat": null, "panels": [], "gridPos": {"x": 0, "y": 0, "w": 24, "h": 1}},
+					{"id": 1, "type": "text", "gridPos": {"x": 0, "y": 1, "w": 12, "h": 7}},
+					{"id": 2, "type": "text", "gridPos": {"x": 12, "y": 1, "w": 12, "h": 3}},
+					{"id": 5, "type": "row", "title": "Row 2", "collapsed": true, "repeat": null, "gridPos": {"x": 0, "y": 8, "w": 24, "h": 1}, "panels": [
+						{"id": 3, "type": "text", "minSpan": 4, "gridPos": {"x": 0, "y": 9, "w": 24, "h": 7}}
+					]}
+				]
+			}`,
+		},
+		{
+			name: "singlestat to stat",
+			data: `{
+				"schemaVersion": 27,
+				"panels": [{
+					"type": "singlestat",
+					"valueName": "current",
+					"format": "percent",
+					"colorBackground": true,
+					"thresholds": "50,80",
+					"colors": ["green", "orange", "red"],
+					"sparkline": {"show": true},
+					"mappingType": 1,
+					"valueMaps": [{"op": "=", "value": "0", "text": "Down"}]
+				}]
+			}`,
+			targetVersion: 28,
+			expected: `{
+				"schemaVersion": 28,
+				"panels": [{
+					"type": "stat",
+					"fieldConfig": {
+						"defaults": {
+							"unit": "percent",
+							"thresholds": {"mode": "absolute", "steps": [
+								{"color": "green", "value": null},
+								{"color": "orange", "value": 50},
+								{"color": "red", "value": 80}
+							]},
+							"mappings": [{"type": "value", "options": {"0": {"text": "Down"}}}]
+						},
+						"overrides": []
+					},
+					"options": {
+						"reduceOptions": {"calcs": ["lastNotNull"], "fields": "", "values": false},
+						"orientation": "horizontal",
+						"textMode": "auto",
+						"justifyMode": "auto",
+						"colorMode": "background",
+						"graphMode": "area"
+					}
+				}]
+			}`,
+		},
+		{
+			name: "datasource names to refs",
+			data: `{
+				"schemaVersion": 30,
+				"panels": [{
+					"type": "graph",
+					"datasource": "-- Mixed --",
+					"targets": [{"datasource": "Prometheus"}, {"datasource": "Unknown"}, {"datasource": null}]
+				}, {
+					"type": "text",
+					"datasource": "default"
+				}],
+				"templating": {"list": [
+					{"type": "query", "datasource": "Prometheus"},
+					{"type": "custom", "datasource": "Prometheus"}
+				]}
+			}`,
+			targetVersion: 36,
+			opt: &MigrateOptions{
+				Datasources: []*Datasource{{UID: "prom", Name: "Prometheus", Type: PrometheusDatasource}},
+			},
+			expected: `{
+				"schemaVersion": 36,
+				"panels": [{
+					"type": "graph",
+					"datasource": {"type": "datasource", "uid": "-- Mixed --"},
+					"targets": [
+						{"datasource": {"type": "prometheus", "uid": "prom"}},
+						{"datasource": {"uid": "Unknown"}},
+						{"datasource": null}
+					]
+				}, {
+					"type": "text",
+					"datasource": null
+				}],
+				"templating": {"list": [
+					{"type": "query", "datasource": {"type": "prometheus", "uid": "prom"}},
+					{"type": "custom", "datasource": "Prometheus"}
+				]}
+			}`,
+		},
+		{
+			name: "graph to timeseries",
+			data: `{
+				"schemaVersion": 36,
+				"panels": [{
+					"type": "graph",
+					"lines": true,
+					"linewidth": 2,
+					"fill": 1,
+					"stack": true,
+					"legend": {"show": true, "alignAsTable": true, "values": true, "max": true},
+					"tooltip": {"shared": true},
+					"yaxes": [{"format": "s", "min": "0", "max": null, "logBase": 1}, {"format": "short"}]
+				}]
+			}`,
+			targetVersion: 36,
+			opt:           &MigrateOptions{GraphToTimeseries: true},
+			expected: `{
+				"schemaVersion": 36,
+				"panels": [{
+					"type": "timeseries",
+					"fieldConfig": {
+						"defaults": {
+							"unit": "s",
+							"min": 0,
+							"custom": {
+								"drawStyle": "line",
+								"lineInterpolation": "linear",
+								"lineWidth": 2,
+								"fillOpacity": 10,
+								"pointSize": 0,
+								"showPoints": "never",
+								"spanNulls": false,
+								"stacking": {"mode": "normal", "group": "A"}
+							}
+						},
+						"overrides": []
+					},
+					"options": {
+						"legend": {"showLegend": true, "displayMode": "table", "placement": "bottom", "calcs": ["max"]},
+						"tooltip": {"mode": "multi"}
+					}
+				}]
+			}`,
+		},
+	}
+
+	for _, tt := range ts {
+		got, err := Migrate([]byte(tt.data), tt.targetVersion, tt.opt)
+		if err != nil {
+			t.Fatalf("Migrate (%s) returned error %s", tt.name, err)
+		}
+
+		if eq, err := JSONBytesEqual([]byte(tt.expected), got); err != nil {
+			t.Fatalf("Migrate (%s) returned error %s", tt.name, err)
+		} else if !eq {
+			t.Errorf("Migrate (%s): got %s, want %s\n", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestMigrate_Decode(t *testing.T) {
+	data := `{
+		"schemaVersion": 27,
+		"panels": [{
+			"id": 1,
+			"type": "singlestat",
+			"datasource": "Prometheus",
+			"gridPos": {"x": 0, "y": 0, "w": 8, "h": 4},
+			"valueName": "avg",
+			"format": "s",
+			"targets": [{"refId": "A", "expr": "up", "intervalFactor": 1}]
+		}, {
+			"id": 2,
+			"type": "graph",
+			"datasource": "Prometheus",
+			"gridPos": {"x": 8, "y": 0, "w": 16, "h": 8},
+			"lines": true,
+			"linewidth": 1,
+			"yaxes": [{"format": "short"}, {"format": "short"}],
+			"targets": [{"refId": "A", "expr": "rate(errors[5m])", "intervalFactor": 1}]
+		}]
+	}`
+	opt := &MigrateOptions{
+		Datasources:       []*Datasource{{UID: "prom", Name: "Prometheus", Type: PrometheusDatasource}},
+		GraphToTimeseries: true,
+	}
+
+	migrated, err := Migrate([]byte(data), 36, opt)
+	if err != nil {
+		t.Fatalf("Migrate returned error %s", err)
+	}
+
+	var d Dashboard
+	if err := json.Unmarshal(migrated, &d); err != nil {
+		t.Fatalf("Dashboard.UnmarshalJSON returned error %s", err)
+	}
+	if len(d.Panels) != 2 {
+		t.Fatalf("Dashboard.UnmarshalJSON: got %d panels, want 2", len(d.Panels))
+	}
+
+	got, err := json.Marshal(&d)
+	if err != nil {
+		t.Fatalf("Dashboard.MarshalJSON returned error %s", err)
+	}
+
+	var want, gotPanels struct {
+		Panels json.RawMessage `json:"panels"`
+	}
+	if err := json.Unmarshal(migrated, &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(got, &gotPanels); err != nil {
+		t.Fatal(err)
+	}
+	if eq, err := JSONBytesEqual(want.Panels, gotPanels.Panels); err != nil {
+		t.Fatalf("JSONBytesEqual returned error %s", err)
+	} else if !eq {
+		t.Errorf("Dashboard.MarshalJSON of migrated dashboard: got panels %s, want %s", gotPanels.Panels, want.Panels)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+// MuteTiming is a named set of time intervals used to mute notifications of
+// policies, e.g. outside business hours.
+type MuteTiming struct {
+	Name          string         `json:"name"`
+	TimeIntervals []TimeInterval `json:"time_intervals"`
+	Provenance    string         `json:"provenance,omitempty"`
+}
+
+// TimeInterval is a recurring interval of time. Empty fields match any time,
+// e.g. interval with only Weekdays matches whole days.
+type TimeInterval struct {
+	Times       []TimeOfDayRange `json:"times,omitempty"`
+	Weekdays    []string         `json:"weekdays,omitempty"`      // e.g. monday:friday
+	DaysOfMonth []string         `json:"days_of_month,omitempty"` // e.g. 1:5 or -1
+	Months      []string         `json:"months,omitempty"`        // e.g. january or 1:3
+	Years       []string         `json:"years,omitempty"`         // e.g. 2024:2025
+	Location    string           `json:"location,omitempty"`      // time zone, e.g. Europe/London
+}
+
+// TimeOfDayRange is a range of time of a day, e.g. from 09:00 to 17:00.
+type TimeOfDayRange struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
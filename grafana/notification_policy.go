@@ -0,0 +1,76 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NotificationPolicy is a node of the tree of notification policies of Grafana
+// alerting. The root policy routes all alerts to its receiver unless they
+// match one of the nested policies. Empty options are inherited from the
+// parent policy.
+type NotificationPolicy struct {
+	Receiver            string                `json:"receiver,omitempty"` // name of contact point
+	GroupBy             []string              `json:"group_by,omitempty"`
+	ObjectMatchers      []Matcher             `json:"object_matchers,omitempty"`
+	Matchers            []string              `json:"matchers,omitempty"` // deprecated, ie. severity="critical"
+	Match               map[string]string     `json:"match,omitempty"`    // deprecated
+	MatchRe             map[string]string     `json:"match_re,omitempty"` // deprecated
+	MuteTimeIntervals   []string              `json:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string              `json:"active_time_intervals,omitempty"`
+	Continue            bool                  `json:"continue,omitempty"` // continue matching sibling policies
+	GroupWait           string                `json:"group_wait,omitempty"`
+	GroupInterval       string                `json:"group_interval,omitempty"`
+	RepeatInterval      string                `json:"repeat_interval,omitempty"`
+	Routes              []*NotificationPolicy `json:"routes,omitempty"`
+	Provenance          string                `json:"provenance,omitempty"`
+}
+
+type matchType string
+
+// Types of matching label values.
+const (
+	EqualMatch     matchType = "="
+	NotEqualMatch  matchType = "!="
+	RegexpMatch    matchType = "=~"
+	NotRegexpMatch matchType = "!~"
+)
+
+// Matcher matches alerts by value of their label.
+type Matcher struct {
+	Label string
+	Type  matchType
+	Value string
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (m Matcher) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]string{m.Label, string(m.Type), m.Value})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (m *Matcher) UnmarshalJSON(data []byte) error {
+	var parts []string
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return err
+	}
+	if len(parts) != 3 {
+		return fmt.Errorf("matcher should have 3 parts, got %d", len(parts))
+	}
+
+	m.Label, m.Type, m.Value = parts[0], matchType(parts[1]), parts[2]
+	return nil
+}
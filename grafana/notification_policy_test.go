@@ -0,0 +1,86 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func TestNotificationPolicy_JSON(t *testing.T) {
+	data := []byte(`{
+		"receiver": "ops",
+		"group_by": ["grafana_folder", "alertname"],
+		"group_wait": "30s",
+		"routes": [
+			{
+				"receiver": "oncall",
+				"object_matchers": [["severity", "=", "critical"], ["team", "=~", "platform|sre"]],
+				"mute_time_intervals": ["weekends"],
+				"continue": true
+			},
+			{
+				"receiver": "legacy",
+				"matchers": ["env=\"prod\""],
+				"match": {"team": "db"},
+				"match_re": {"service": "pg.*"}
+			}
+		]
+	}`)
+
+	var got NotificationPolicy
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("NotificationPolicy.UnmarshalJSON returned error %s", err)
+	}
+
+	expected := NotificationPolicy{
+		Receiver:  "ops",
+		GroupBy:   []string{"grafana_folder", "alertname"},
+		GroupWait: "30s",
+		Routes: []*NotificationPolicy{{
+			Receiver: "oncall",
+			ObjectMatchers: []Matcher{
+				{Label: "severity", Type: EqualMatch, Value: "critical"},
+				{Label: "team", Type: RegexpMatch, Value: "platform|sre"},
+			},
+			MuteTimeIntervals: []string{"weekends"},
+			Continue:          true,
+		}, {
+			Receiver: "legacy",
+			Matchers: []string{`env="prod"`},
+			Match:    map[string]string{"team": "db"},
+			MatchRe:  map[string]string{"service": "pg.*"},
+		}},
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("NotificationPolicy.UnmarshalJSON: %s", pretty.Diff(expected, got))
+	}
+
+	b, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatalf("NotificationPolicy.MarshalJSON returned error %s", err)
+	}
+	if eq, err := JSONBytesEqual(data, b); err != nil {
+		t.Fatalf("NotificationPolicy.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("NotificationPolicy.MarshalJSON:\ngot %s\nwant %s", b, data)
+	}
+
+	if err := json.Unmarshal([]byte(`["severity", "="]`), new(Matcher)); err == nil {
+		t.Errorf("Matcher.UnmarshalJSON: expected error for incomplete matcher")
+	}
+}
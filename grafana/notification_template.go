@@ -0,0 +1,23 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+// NotificationTemplate is a named Go template used in messages of contact
+// points.
+type NotificationTemplate struct {
+	Name       string `json:"name"`
+	Template   string `json:"template"`
+	Provenance string `json:"provenance,omitempty"`
+	Version    string `json:"version,omitempty"` // for optimistic locking on update
+}
@@ -13,9 +13,44 @@
 
 package grafana
 
+import "time"
+
 type OrgID uint
 
 type Org struct {
-	ID   OrgID  `json:"id"`
-	Name string `json:"name"`
+	ID      OrgID       `json:"id"`
+	Name    string      `json:"name"`
+	Address *OrgAddress `json:"address,omitempty"`
+}
+
+// OrgAddress is a postal address of an organization.
+type OrgAddress struct {
+	Address1 string `json:"address1"`
+	Address2 string `json:"address2"`
+	City     string `json:"city"`
+	ZipCode  string `json:"zipCode"`
+	State    string `json:"state"`
+	Country  string `json:"country"`
+}
+
+// OrgRole is a role of a user in an organization.
+type OrgRole string
+
+const (
+	ViewerOrgRole OrgRole = "Viewer"
+	EditorOrgRole OrgRole = "Editor"
+	AdminOrgRole  OrgRole = "Admin"
+)
+
+// OrgUser is a user who is a member of an organization.
+type OrgUser struct {
+	OrgID         OrgID     `json:"orgId"`
+	UserID        UserID    `json:"userId"`
+	Email         string    `json:"email"`
+	Login         string    `json:"login"`
+	Name          string    `json:"name"`
+	AvatarURL     string    `json:"avatarUrl"`
+	Role          OrgRole   `json:"role"`
+	LastSeenAt    time.Time `json:"lastSeenAt"`
+	LastSeenAtAge string    `json:"lastSeenAtAge"`
 }
@@ -0,0 +1,325 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panel
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
+type alertNoDataState string
+
+// States that alert is set to if query returns no data.
+const (
+	NoDataAlertState         alertNoDataState = "no_data"
+	AlertingNoDataAlertState alertNoDataState = "alerting"
+	OKNoDataAlertState       alertNoDataState = "ok"
+	KeepLastNoDataAlertState alertNoDataState = "keep_state"
+)
+
+type alertErrorState string
+
+// States that alert is set to if evaluation fails.
+const (
+	AlertingErrorAlertState alertErrorState = "alerting"
+	KeepLastErrorAlertState alertErrorState = "keep_state"
+)
+
+type alertReducerType string
+
+// Functions reducing series of alert's query to a single value.
+const (
+	AvgAlertReducer            alertReducerType = "avg"
+	MinAlertReducer            alertReducerType = "min"
+	MaxAlertReducer            alertReducerType = "max"
+	SumAlertReducer            alertReducerType = "sum"
+	CountAlertReducer          alertReducerType = "count"
+	LastAlertReducer           alertReducerType = "last"
+	MedianAlertReducer         alertReducerType = "median"
+	DiffAlertReducer           alertReducerType = "diff"
+	DiffAbsAlertReducer        alertReducerType = "diff_abs"
+	PercentDiffAlertReducer    alertReducerType = "percent_diff"
+	PercentDiffAbsAlertReducer alertReducerType = "percent_diff_abs"
+	CountNonNullAlertReducer   alertReducerType = "count_non_null"
+)
+
+func (r alertReducerType) valid() bool {
+	switch r {
+	case AvgAlertReducer, MinAlertReducer, MaxAlertReducer, SumAlertReducer, CountAlertReducer,
+		LastAlertReducer, MedianAlertReducer, DiffAlertReducer, DiffAbsAlertReducer,
+		PercentDiffAlertReducer, PercentDiffAbsAlertReducer, CountNonNullAlertReducer:
+		return true
+	}
+	return false
+}
+
+type alertEvaluatorType string
+
+// Types of evaluators checking reduced value of alert's query.
+const (
+	GreaterAlertEvaluator      alertEvaluatorType = "gt"
+	LessAlertEvaluator         alertEvaluatorType = "lt"
+	OutsideRangeAlertEvaluator alertEvaluatorType = "outside_range"
+	WithinRangeAlertEvaluator  alertEvaluatorType = "within_range"
+	NoValueAlertEvaluator      alertEvaluatorType = "no_value"
+)
+
+// paramCount returns a number of params that evaluator of type t takes or -1
+// if t is unknown.
+func (t alertEvaluatorType) paramCount() int {
+	switch t {
+	case GreaterAlertEvaluator, LessAlertEvaluator:
+		return 1
+	case OutsideRangeAlertEvaluator, WithinRangeAlertEvaluator:
+		return 2
+	case NoValueAlertEvaluator:
+		return 0
+	}
+	return -1
+}
+
+type alertOperatorType string
+
+// Operators combining alert's condition with the previous ones.
+const (
+	AndAlertOperator alertOperatorType = "and"
+	OrAlertOperator  alertOperatorType = "or"
+)
+
+// Alert is a legacy alert rule defined in a graph panel. Since Grafana 8 alert
+// rules are defined separately from dashboards, but old dashboards still keep
+// them in panels.
+type Alert struct {
+	Name                string            `json:"name"`
+	Message             string            `json:"message"`
+	Frequency           string            `json:"frequency"` // how often the rule is evaluated, e.g. 1m
+	For                 string            `json:"for"`       // how long the rule should fail before alerting
+	Handler             int               `json:"handler"`   // always 1
+	NoDataState         alertNoDataState  `json:"noDataState"`
+	ExecutionErrorState alertErrorState   `json:"executionErrorState"`
+	Conditions          []AlertCondition  `json:"conditions"`
+	Notifications       []AlertNotifier   `json:"notifications"`
+	AlertRuleTags       map[string]string `json:"alertRuleTags"`
+}
+
+// NewAlert creates a new Alert with defaults of Grafana UI.
+func NewAlert(name string) *Alert {
+	return &Alert{
+		Name:                name,
+		Frequency:           "1m",
+		For:                 "5m",
+		Handler:             1,
+		NoDataState:         NoDataAlertState,
+		ExecutionErrorState: AlertingErrorAlertState,
+		Conditions:          []AlertCondition{},
+		Notifications:       []AlertNotifier{},
+		AlertRuleTags:       map[string]string{},
+	}
+}
+
+// Notify adds notification channel with given uid to alert's notifications
+// unless it's already there.
+func (a *Alert) Notify(uid string) {
+	for _, n := range a.Notifications {
+		if n.UID == uid {
+			return
+		}
+	}
+	a.Notifications = append(a.Notifications, AlertNotifier{UID: uid})
+}
+
+// alertIntervalRegexp matches frequency and pending period of alert.
+var alertIntervalRegexp = regexp.MustCompile(`^\d+[smhdwy]$`)
+
+// Validate checks that alert's options and conditions are valid.
+func (a *Alert) Validate() error {
+	var errs validate.Errors
+	if a.Name == "" {
+		errs.Add("/name", "should not be empty")
+	}
+	if !alertIntervalRegexp.MatchString(a.Frequency) {
+		errs.Add("/frequency", "invalid interval %q", a.Frequency)
+	}
+	if a.For != "" && !alertIntervalRegexp.MatchString(a.For) {
+		errs.Add("/for", "invalid interval %q", a.For)
+	}
+	switch a.NoDataState {
+	case NoDataAlertState, AlertingNoDataAlertState, OKNoDataAlertState, KeepLastNoDataAlertState:
+	default:
+		errs.Add("/noDataState", "unknown state %q", a.NoDataState)
+	}
+	switch a.ExecutionErrorState {
+	case AlertingErrorAlertState, KeepLastErrorAlertState:
+	default:
+		errs.Add("/executionErrorState", "unknown state %q", a.ExecutionErrorState)
+	}
+
+	if len(a.Conditions) == 0 {
+		errs.Add("/conditions", "should not be empty")
+	}
+	for i, c := range a.Conditions {
+		errs.Merge(validate.Path("conditions", i), c.Validate())
+	}
+	for i, n := range a.Notifications {
+		if n.ID == 0 && n.UID == "" {
+			errs.Add(validate.Path("notifications", i), "should have id or uid")
+		}
+	}
+
+	return errs.Err()
+}
+
+// Thresholds returns thresholds showing when alert fires. Grafana draws these
+// thresholds on graph instead of the panel's ones. Like Grafana, only the first
+// condition is used.
+func (a *Alert) Thresholds() []Threshold {
+	if len(a.Conditions) == 0 {
+		return nil
+	}
+
+	newThreshold := func(op operator, value float64) Threshold {
+		return Threshold{Mode: CriticalThresholdMode, Fill: true, Line: true, Op: op, Value: value}
+	}
+	e := a.Conditions[0].Evaluator
+	if len(e.Params) < e.Type.paramCount() {
+		return nil
+	}
+	switch e.Type {
+	case GreaterAlertEvaluator:
+		return []Threshold{newThreshold(GreaterOp, e.Params[0])}
+	case LessAlertEvaluator:
+		return []Threshold{newThreshold(LessOp, e.Params[0])}
+	case OutsideRangeAlertEvaluator:
+		return []Threshold{newThreshold(LessOp, e.Params[0]), newThreshold(GreaterOp, e.Params[1])}
+	case WithinRangeAlertEvaluator:
+		return []Threshold{newThreshold(GreaterOp, e.Params[0]), newThreshold(LessOp, e.Params[1])}
+	}
+	return nil
+}
+
+// AlertCondition is a condition of alert that checks a reduced value of one of
+// panel's queries.
+type AlertCondition struct {
+	Type      string         `json:"type"` // always query
+	Query     AlertQuery     `json:"query"`
+	Reducer   AlertReducer   `json:"reducer"`
+	Evaluator AlertEvaluator `json:"evaluator"`
+	Operator  AlertOperator  `json:"operator"`
+}
+
+// NewAlertCondition creates a new AlertCondition that is combined with the
+// previous ones with "and" operator.
+func NewAlertCondition(query AlertQuery, reducer alertReducerType, evaluator AlertEvaluator) AlertCondition {
+	return AlertCondition{
+		Type:      "query",
+		Query:     query,
+		Reducer:   AlertReducer{Type: reducer, Params: []string{}},
+		Evaluator: evaluator,
+		Operator:  AlertOperator{Type: AndAlertOperator},
+	}
+}
+
+// Validate checks that condition's query, reducer, evaluator and operator are
+// valid.
+func (c AlertCondition) Validate() error {
+	var errs validate.Errors
+	if c.Query.RefID == "" {
+		errs.Add("/query/params/0", "should not be empty")
+	}
+	if c.Query.From == "" {
+		errs.Add("/query/params/1", "should not be empty")
+	}
+	if !c.Reducer.Type.valid() {
+		errs.Add("/reducer/type", "unknown reducer %q", c.Reducer.Type)
+	}
+	if n := c.Evaluator.Type.paramCount(); n < 0 {
+		errs.Add("/evaluator/type", "unknown evaluator %q", c.Evaluator.Type)
+	} else if len(c.Evaluator.Params) != n {
+		errs.Add("/evaluator/params", "evaluator %s takes %d params, got %d", c.Evaluator.Type, n, len(c.Evaluator.Params))
+	} else if n == 2 && c.Evaluator.Params[0] > c.Evaluator.Params[1] {
+		errs.Add("/evaluator/params", "range start %v is greater than its end %v", c.Evaluator.Params[0], c.Evaluator.Params[1])
+	}
+	switch c.Operator.Type {
+	case AndAlertOperator, OrAlertOperator:
+	default:
+		errs.Add("/operator/type", "should be %s or %s, got %q", AndAlertOperator, OrAlertOperator, c.Operator.Type)
+	}
+
+	return errs.Err()
+}
+
+// AlertQuery refers to panel's query with refID and sets time range that the
+// query is evaluated for, e.g. from 5m to now.
+type AlertQuery struct {
+	RefID string
+	From  string
+	To    string
+}
+
+// NewAlertQuery creates a new AlertQuery evaluating query with given refID for
+// time range from given time to now.
+func NewAlertQuery(refID, from string) AlertQuery {
+	return AlertQuery{RefID: refID, From: from, To: "now"}
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (q AlertQuery) MarshalJSON() ([]byte, error) {
+	params := struct {
+		Params []string `json:"params"`
+	}{[]string{q.RefID, q.From, q.To}}
+	return json.Marshal(params)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (q *AlertQuery) UnmarshalJSON(data []byte) error {
+	var params struct {
+		Params []string `json:"params"`
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	if len(params.Params) != 3 {
+		return fmt.Errorf("alert query should have 3 params, got %d", len(params.Params))
+	}
+
+	q.RefID, q.From, q.To = params.Params[0], params.Params[1], params.Params[2]
+	return nil
+}
+
+// AlertReducer reduces series returned by alert's query to a single value.
+type AlertReducer struct {
+	Type   alertReducerType `json:"type"`
+	Params []string         `json:"params"`
+}
+
+// AlertEvaluator checks a reduced value of alert's query.
+type AlertEvaluator struct {
+	Type   alertEvaluatorType `json:"type"`
+	Params []float64          `json:"params"`
+}
+
+// AlertOperator combines alert's condition with the previous ones.
+type AlertOperator struct {
+	Type alertOperatorType `json:"type"`
+}
+
+// AlertNotifier refers to a notification channel that alert is sent to. Old
+// dashboards refer to channels by ID, the newer ones by UID.
+type AlertNotifier struct {
+	ID  uint   `json:"id,omitempty"`
+	UID string `json:"uid,omitempty"`
+}
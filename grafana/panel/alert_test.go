@@ -0,0 +1,172 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panel_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/grafana/query"
+	jsontools "github.com/utilitywarehouse/go-grafana/pkg/json"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
+func TestAlert_JSON(t *testing.T) {
+	data := []byte(`{
+		"alertRuleTags": {"team": "platform"},
+		"conditions": [{
+			"evaluator": {"params": [0.5, 2], "type": "outside_range"},
+			"operator": {"type": "and"},
+			"query": {"params": ["A", "5m", "now"]},
+			"reducer": {"params": [], "type": "avg"},
+			"type": "query"
+		}],
+		"executionErrorState": "alerting",
+		"for": "5m",
+		"frequency": "1m",
+		"handler": 1,
+		"message": "Latency is out of range",
+		"name": "Latency alert",
+		"noDataState": "no_data",
+		"notifications": [{"uid": "slack"}, {"id": 2}]
+	}`)
+
+	var got panel.Alert
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Alert.UnmarshalJSON returned error %s", err)
+	}
+
+	expected := panel.NewAlert("Latency alert")
+	expected.Message = "Latency is out of range"
+	expected.AlertRuleTags["team"] = "platform"
+	expected.Conditions = []panel.AlertCondition{
+		panel.NewAlertCondition(
+			panel.NewAlertQuery("A", "5m"),
+			panel.AvgAlertReducer,
+			panel.AlertEvaluator{Type: panel.OutsideRangeAlertEvaluator, Params: []float64{0.5, 2}},
+		),
+	}
+	expected.Notifications = []panel.AlertNotifier{{UID: "slack"}, {ID: 2}}
+	if !reflect.DeepEqual(expected, &got) {
+		t.Errorf("Alert.UnmarshalJSON: %s", pretty.Diff(expected, &got))
+	}
+
+	b, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatalf("Alert.MarshalJSON returned error %s", err)
+	}
+	if eq, err := jsontools.BytesEqual(data, b); err != nil {
+		t.Fatalf("Alert.MarshalJSON returned error %s", err)
+	} else if !eq {
+		t.Errorf("Alert.MarshalJSON:\ngot %s\nwant: %s", b, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"params": ["A"]}`), new(panel.AlertQuery)); err == nil {
+		t.Errorf("AlertQuery.UnmarshalJSON: expected error for incomplete params")
+	}
+}
+
+func TestAlert_Notify(t *testing.T) {
+	a := panel.NewAlert("alert")
+	a.Notifications = append(a.Notifications, panel.AlertNotifier{ID: 1})
+	a.Notify("ops")
+	a.Notify("oncall")
+	a.Notify("ops")
+
+	expected := []panel.AlertNotifier{{ID: 1}, {UID: "ops"}, {UID: "oncall"}}
+	if !reflect.DeepEqual(a.Notifications, expected) {
+		t.Errorf("Alert.Notify: %s", pretty.Diff(expected, a.Notifications))
+	}
+}
+
+func TestGraph_SetAlert(t *testing.T) {
+	ts := []struct {
+		evaluator panel.AlertEvaluator
+		expected  []panel.Threshold
+	}{
+		{
+			panel.AlertEvaluator{Type: panel.GreaterAlertEvaluator, Params: []float64{10}},
+			[]panel.Threshold{{Mode: panel.CriticalThresholdMode, Fill: true, Line: true, Op: panel.GreaterOp, Value: 10}},
+		},
+		{
+			panel.AlertEvaluator{Type: panel.WithinRangeAlertEvaluator, Params: []float64{1, 2.5}},
+			[]panel.Threshold{
+				{Mode: panel.CriticalThresholdMode, Fill: true, Line: true, Op: panel.GreaterOp, Value: 1},
+				{Mode: panel.CriticalThresholdMode, Fill: true, Line: true, Op: panel.LessOp, Value: 2.5},
+			},
+		},
+		{
+			panel.AlertEvaluator{Type: panel.NoValueAlertEvaluator, Params: []float64{}},
+			[]panel.Threshold{},
+		},
+	}
+
+	for _, tt := range ts {
+		p := panel.NewGraph()
+		p.Thresholds = []panel.Threshold{{Mode: panel.WarningThresholdMode, Op: panel.GreaterOp, Value: 5}}
+
+		a := panel.NewAlert("alert")
+		a.Conditions = append(a.Conditions, panel.NewAlertCondition(panel.NewAlertQuery("A", "5m"), panel.LastAlertReducer, tt.evaluator))
+		p.SetAlert(a)
+		if !reflect.DeepEqual(p.Thresholds, tt.expected) {
+			t.Errorf("Graph.SetAlert (%s): %s", tt.evaluator.Type, pretty.Diff(tt.expected, p.Thresholds))
+		}
+	}
+
+	p := panel.NewGraph()
+	p.SetAlert(nil)
+	if p.Alert != nil || len(p.Thresholds) != 0 {
+		t.Errorf("Graph.SetAlert (nil): expected no alert and thresholds, got %v and %v", p.Alert, p.Thresholds)
+	}
+}
+
+func TestGraph_Validate_Alert(t *testing.T) {
+	p := panel.NewGraph()
+	q := query.NewPrometheus("Prometheus")
+	q.Expression = "up"
+	q.SetRefID("A")
+	*p.Queries() = []panel.Query{q}
+
+	a := panel.NewAlert("")
+	a.Frequency = "1 minute"
+	a.Conditions = []panel.AlertCondition{
+		panel.NewAlertCondition(panel.NewAlertQuery("A", "5m"), panel.AvgAlertReducer,
+			panel.AlertEvaluator{Type: panel.GreaterAlertEvaluator, Params: []float64{1}}),
+		panel.NewAlertCondition(panel.NewAlertQuery("B", "5m"), "mean",
+			panel.AlertEvaluator{Type: panel.OutsideRangeAlertEvaluator, Params: []float64{1}}),
+	}
+	p.SetAlert(a)
+
+	errs, ok := p.Validate().(validate.Errors)
+	if !ok {
+		t.Fatalf("Graph.Validate: expected validate.Errors")
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	expected := []string{
+		"/alert/name",
+		"/alert/frequency",
+		"/alert/conditions/1/reducer/type",
+		"/alert/conditions/1/evaluator/params",
+		"/alert/conditions/1/query/params/0",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Graph.Validate: got errors of %v, want %v\n%s", got, expected, errs)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panel
+
+import "encoding/json"
+
+// DatasourceType is type of datasource, ie. prometheus.
+type DatasourceType string
+
+// DatasourceRef refers a datasource from panel or query. Dashboards of schemaVersion < 33
+// refer datasources by name, the newer ones by uid and type.
+type DatasourceRef struct {
+	Name string
+	UID  string
+	Type DatasourceType
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (r DatasourceRef) MarshalJSON() ([]byte, error) {
+	if r.UID == "" && r.Type == "" {
+		if r.Name == "" {
+			return []byte("null"), nil
+		}
+		return json.Marshal(r.Name)
+	}
+
+	jr := struct {
+		Type DatasourceType `json:"type,omitempty"`
+		UID  string         `json:"uid,omitempty"`
+	}{
+		Type: r.Type,
+		UID:  r.UID,
+	}
+	return json.Marshal(jr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (r *DatasourceRef) UnmarshalJSON(data []byte) error {
+	var val interface{}
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	*r = DatasourceRef{}
+	switch v := val.(type) {
+	case string:
+		r.Name = v
+	case map[string]interface{}:
+		uid, _ := v["uid"].(string)
+		dsType, _ := v["type"].(string)
+		r.UID = uid
+		r.Type = DatasourceType(dsType)
+	}
+
+	return nil
+}
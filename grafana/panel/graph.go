@@ -16,9 +16,11 @@ package panel
 import (
 	"encoding/json"
 	"errors"
+	"strconv"
 
 	"github.com/guregu/null"
 	"github.com/utilitywarehouse/go-grafana/pkg/field"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 type graphXAxisMode string
@@ -52,6 +54,14 @@ const (
 	NullAsZeroPointMode    nullPointMode = "null as zero"
 )
 
+func (m nullPointMode) valid() bool {
+	switch m {
+	case "", ConnectedNullPointMode, NullNullPointMode, NullAsZeroPointMode:
+		return true
+	}
+	return false
+}
+
 type thresholdMode string
 
 const (
@@ -75,7 +85,7 @@ type Graph struct {
 	YAxes GraphYaxesOptions `json:"yaxes"`
 	XAxis struct {
 		Buckets null.Int       `json:"buckets,omitempty"`
-		Mode    graphXAxisMode `json:"mode"`           // TODO: add validaition here: histogram/series/time
+		Mode    graphXAxisMode `json:"mode"`           // histogram/series/time
 		Name    *string        `json:"name,omitempty"` // it's seems that it's not used anymore
 		Show    bool           `json:"show"`
 		Values  []string       `json:"values"` // TODO: actually it's only single value here. Need custom type
@@ -110,6 +120,10 @@ type Graph struct {
 	SeriesOverrides []GraphSeriesOverride `json:"seriesOverrides"`
 	Thresholds      []Threshold           `json:"thresholds"`
 
+	// Alert is a legacy alert rule of the panel. Use SetAlert to keep
+	// thresholds in sync with it.
+	Alert *Alert `json:"alert,omitempty"`
+
 	// Time range
 	TimeRangeOptions
 
@@ -122,6 +136,103 @@ func NewGraph() *Graph {
 	return &Graph{}
 }
 
+// Validate checks that panel's options are valid.
+func (p *Graph) Validate() error {
+	var errs validate.Errors
+	errs.Merge("", p.generalOptions.Validate())
+
+	errs.Merge("/yaxes/0", p.YAxes.Left.Validate())
+	errs.Merge("/yaxes/1", p.YAxes.Right.Validate())
+	switch p.XAxis.Mode {
+	case "", graphXAxisHistogram, graphXAxisSeries, graphXAxisTime:
+	default:
+		errs.Add("/xaxis/mode", "should be %s, %s or %s, got %q", graphXAxisHistogram, graphXAxisSeries, graphXAxisTime, p.XAxis.Mode)
+	}
+
+	if p.Fill > 10 {
+		errs.Add("/fill", "should be 0-10, got %d", p.Fill)
+	}
+	if p.LineWidth > 10 {
+		errs.Add("/linewidth", "should be 0-10, got %d", p.LineWidth)
+	}
+	if !p.NullValue.valid() {
+		errs.Add("/nullPointMode", "unknown mode %q", p.NullValue)
+	}
+	if p.Tooltip.Sort > Desc {
+		errs.Add("/tooltip/sort", "should be 0-%d, got %d", Desc, p.Tooltip.Sort)
+	}
+	switch p.Tooltip.StackedValue {
+	case "", Individual, Cumulative:
+	default:
+		errs.Add("/tooltip/value_type", "should be %s or %s, got %q", Individual, Cumulative, p.Tooltip.StackedValue)
+	}
+
+	for i, o := range p.SeriesOverrides {
+		path := validate.Path("seriesOverrides", i)
+		if o.Alias == "" {
+			errs.Add(path+"/alias", "should not be empty")
+		}
+		if o.YAxis != nil && *o.YAxis != 1 && *o.YAxis != 2 {
+			errs.Add(path+"/yaxis", "should be 1 or 2, got %d", *o.YAxis)
+		}
+		if !o.NullPointMode.valid() {
+			errs.Add(path+"/nullPointMode", "unknown mode %q", o.NullPointMode)
+		}
+	}
+	for i, t := range p.Thresholds {
+		path := validate.Path("thresholds", i)
+		switch t.Mode {
+		case CustomThresholdMode, CriticalThresholdMode, WarningThresholdMode, OKThresholdMode:
+		default:
+			errs.Add(path+"/colorMode", "unknown mode %q", t.Mode)
+		}
+		if t.Op != GreaterOp && t.Op != LessOp {
+			errs.Add(path+"/op", "should be %s or %s, got %q", GreaterOp, LessOp, t.Op)
+		}
+	}
+
+	if p.Alert != nil {
+		errs.Merge("/alert", p.Alert.Validate())
+		p.validateAlertQueries(&errs)
+	}
+
+	validateQueries(&errs, p.queries)
+
+	return errs.Err()
+}
+
+// validateAlertQueries checks that alert's conditions refer to panel's queries.
+// It's skipped if some queries don't have refId yet, since they get it only on
+// marshaling.
+func (p *Graph) validateAlertQueries(errs *validate.Errors) {
+	refIDs := make(map[string]bool)
+	for _, q := range p.queries {
+		if r, ok := q.(QueryRef); ok && r.RefID() != "" {
+			refIDs[r.RefID()] = true
+		}
+	}
+	if len(refIDs) == 0 || len(refIDs) != len(p.queries) {
+		return
+	}
+
+	for i, c := range p.Alert.Conditions {
+		if c.Query.RefID != "" && !refIDs[c.Query.RefID] {
+			errs.Add(validate.Path("alert", "conditions", i, "query", "params", 0), "unknown query %q", c.Query.RefID)
+		}
+	}
+}
+
+// SetAlert sets alert rule of the panel and replaces its thresholds with the
+// ones of the alert, the same way Grafana UI does. Nil alert removes the rule
+// and its thresholds.
+func (p *Graph) SetAlert(a *Alert) {
+	p.Alert = a
+	p.Thresholds = []Threshold{}
+	if a != nil {
+		p.Thresholds = append(p.Thresholds, a.Thresholds()...)
+	}
+}
+
 type DrawOptions struct {
 	Bars   bool `json:"bars"`
 	Lines  bool `json:"lines"`
@@ -175,12 +286,43 @@ func (y *GraphYaxesOptions) UnmarshalJSON(data []byte) error {
 type GraphYAxis struct {
 	Format  string             `json:"format"` // TODO: replace with custom type with default value "short".
 	Label   string             `json:"label,omitempty"`
-	LogBase int                `json:"logBase"` // TODO: default value should be 1 (linear)
+	LogBase int                `json:"logBase"` // 1 (linear), 2, 10, 32 or 1024
 	Max     *field.ForceString `json:"max"`
 	Min     *field.ForceString `json:"min"`
 	Show    bool               `json:"show"`
 }
 
+// Validate checks axis's scale and bounds.
+func (a GraphYAxis) Validate() error {
+	var errs validate.Errors
+	switch a.LogBase {
+	case 0, 1, 2, 10, 32, 1024: // zero means linear as well
+	default:
+		errs.Add("/logBase", "should be 1 (linear), 2, 10, 32 or 1024, got %d", a.LogBase)
+	}
+
+	min, hasMin := parseAxisBound(&errs, "/min", a.Min)
+	max, hasMax := parseAxisBound(&errs, "/max", a.Max)
+	if hasMin && hasMax && max <= min {
+		errs.Add("/max", "should be greater than min %s, got %s", *a.Min, *a.Max)
+	}
+
+	return errs.Err()
+}
+
+// parseAxisBound parses axis's bound if it's set.
+func parseAxisBound(errs *validate.Errors, path string, bound *field.ForceString) (float64, bool) {
+	if bound == nil || *bound == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(string(*bound), 64)
+	if err != nil {
+		errs.Add(path, "%q is not a number", *bound)
+		return 0, false
+	}
+	return v, true
+}
+
 type GraphSeriesOverride struct {
 	Alias         string        `json:"alias"`
 	Bars          *bool         `json:"bars"`
@@ -208,6 +350,6 @@ type Threshold struct {
 	Fill  bool          `json:"fill"`
 	Line  bool          `json:"line"`
 	Op    operator      `json:"op"`
-	Value int           `json:"value"`
+	Value float64       `json:"value"`
 	Color string        `json:"lineColor,omitempty"` // TODO: replace with custom field
 }
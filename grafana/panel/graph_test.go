@@ -9,6 +9,7 @@ import (
 	"github.com/kr/pretty"
 	"github.com/utilitywarehouse/go-grafana/pkg/field"
 	jsontools "github.com/utilitywarehouse/go-grafana/pkg/json"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 func TestGraph_MarshalJSON(t *testing.T) {
@@ -402,3 +403,31 @@ func intRef(i int) *int {
 func uintRef(i uint) *uint {
 	return &i
 }
+
+func TestGraph_Validate(t *testing.T) {
+	p := NewGraph()
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Graph.Validate returned error %s", err)
+	}
+
+	min, max := field.ForceString("10"), field.ForceString("5")
+	p.YAxes.Right.Min = &min
+	p.YAxes.Right.Max = &max
+	p.XAxis.Mode = "bars"
+	p.Fill = 11
+	p.SeriesOverrides = []GraphSeriesOverride{{Alias: "errors", YAxis: uintRef(3)}}
+	p.Thresholds = []Threshold{{Mode: CriticalThresholdMode, Op: EqualSignOp}}
+
+	errs, ok := p.Validate().(validate.Errors)
+	if !ok {
+		t.Fatalf("Graph.Validate: expected validate.Errors")
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	expected := []string{"/yaxes/1/max", "/xaxis/mode", "/fill", "/seriesOverrides/0/yaxis", "/thresholds/0/op"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Graph.Validate: got errors of %v, want %v\n%s", got, expected, errs)
+	}
+}
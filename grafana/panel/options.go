@@ -16,16 +16,64 @@ package panel
 import (
 	"github.com/guregu/null"
 	"github.com/utilitywarehouse/go-grafana/pkg/field"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 type GeneralOptions struct {
+	ID          uint              `json:"id"`
 	Description string            `json:"description"`
+	GridPos     *GridPos          `json:"gridPos,omitempty"` // used instead of Span/Height since schemaVersion 16
 	Height      field.ForceString `json:"height"`
 	Links       []PanelLink       `json:"links"`
-	MinSpan     uint              `json:"minSpan"` // TODO: valid values: 1-12
-	Span        uint              `json:"span"`    // TODO: valid values: 1-12
+	MinSpan     uint              `json:"minSpan"` // valid values: 1-12
+	Span        uint              `json:"span"`    // valid values: 1-12
 	Title       string            `json:"title"`
 	Transparent bool              `json:"transparent"`
+
+	// SnapshotData is data shown by panel of a dashboard snapshot instead of
+	// results of its queries.
+	SnapshotData []SnapshotSeries `json:"snapshotData,omitempty"`
+}
+
+// Validate checks that panel's size, position and links are valid.
+func (o *GeneralOptions) Validate() error {
+	var errs validate.Errors
+	if o.Span > LegacyColumnCount {
+		errs.Add("/span", "should be 1-%d, got %d", LegacyColumnCount, o.Span)
+	}
+	if o.MinSpan > LegacyColumnCount {
+		errs.Add("/minSpan", "should be 1-%d, got %d", LegacyColumnCount, o.MinSpan)
+	}
+	if pos := o.GridPos; pos != nil {
+		if pos.W == 0 || pos.W > GridColumnCount {
+			errs.Add("/gridPos/w", "should be 1-%d, got %d", GridColumnCount, pos.W)
+		} else if pos.X+pos.W > GridColumnCount {
+			errs.Add("/gridPos/x", "panel of width %d doesn't fit into the grid at %d", pos.W, pos.X)
+		}
+		if pos.H == 0 {
+			errs.Add("/gridPos/h", "should not be zero")
+		}
+	}
+	for i, link := range o.Links {
+		errs.Merge(validate.Path("links", i), link.Validate())
+	}
+
+	return errs.Err()
+}
+
+// GridColumnCount is a number of columns of dashboard's grid.
+const GridColumnCount = 24
+
+// LegacyColumnCount is a number of columns of dashboard's rows before
+// schemaVersion 16.
+const LegacyColumnCount = 12
+
+// GridPos is a position and a size of panel on dashboard's grid.
+type GridPos struct {
+	X uint `json:"x"`
+	Y uint `json:"y"`
+	W uint `json:"w"` // valid values: 1-24
+	H uint `json:"h"`
 }
 
 type panelLinkType string
@@ -47,10 +95,29 @@ type PanelLink struct {
 	URL   string `json:"url,omitempty"`
 
 	// type=dashboard
-	DashboardURI string `json:"dashUri,omitempty"`   // TODO: validation. should be valid dashboard
+	DashboardURI string `json:"dashUri,omitempty"`   // should be valid dashboard
 	Dashboard    string `json:"dashboard,omitempty"` // actually it's title. Autofilled from dashboard title
 }
 
+// Validate checks that link has a target of its type.
+func (l PanelLink) Validate() error {
+	var errs validate.Errors
+	switch l.Type {
+	case PanelLinkAbsolute:
+		if l.URL == "" {
+			errs.Add("/url", "should not be empty")
+		}
+	case PanelLinkDashboard:
+		if l.DashboardURI == "" {
+			errs.Add("/dashUri", "should not be empty")
+		}
+	default:
+		errs.Add("/type", "should be %s or %s, got %q", PanelLinkAbsolute, PanelLinkDashboard, l.Type)
+	}
+
+	return errs.Err()
+}
+
 // NewPanelLink creates new PanelLink
 func NewPanelLink(linkType panelLinkType) *PanelLink {
 	return &PanelLink{
@@ -61,7 +128,24 @@ func NewPanelLink(linkType panelLinkType) *PanelLink {
 
 // Query is interface describes behaviour that we want from panel's queries
 type Query interface {
-	Datasource() string
+	Datasource() DatasourceRef
+	SetDatasource(ref DatasourceRef)
+}
+
+// QueryRef is interface of queries that keep their reference ID (refId). Queries
+// without refId get one on marshaling of panel.
+type QueryRef interface {
+	RefID() string
+	SetRefID(refID string)
+}
+
+// validateQueries validates panel's queries that have Validate method.
+func validateQueries(errs *validate.Errors, queries []Query) {
+	for i, q := range queries {
+		if v, ok := q.(interface{ Validate() error }); ok {
+			errs.Merge(validate.Path("targets", i), v.Validate())
+		}
+	}
 }
 
 type TimeRangeOptions struct {
@@ -13,7 +13,14 @@
 
 package panel
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
 
 type valueMappingType uint
 
@@ -28,11 +35,11 @@ type Singlestat struct {
 
 	// Options. Value.
 	ValueName       string `json:"valueName"`     // Stat: min/max/avg/current/total/name/first/delta/diff/range
-	ValueFontSize   string `json:"valueFontSize"` // 0%-100% TODO: validation
+	ValueFontSize   string `json:"valueFontSize"` // 0%-100%
 	Postfix         string `json:"postfix"`
-	PostfixFontSize string `json:"postfixFontSize"` // 0%-100% TODO: validation
+	PostfixFontSize string `json:"postfixFontSize"` // 0%-100%
 	Prefix          string `json:"prefix"`
-	PrefixFontSize  string `json:"prefixFontSize"` // 0%-100% TODO: validation
+	PrefixFontSize  string `json:"prefixFontSize"` // 0%-100%
 	Format          string `json:"format"`         // Unit option. TODO: make a custom type with constants
 
 	// Options. Coloring.
@@ -40,7 +47,7 @@ type Singlestat struct {
 	ColorBackground bool `json:"colorBackground"`
 	// Colorize value or not
 	ColorValue bool     `json:"colorValue"`
-	Thresholds string   `json:"thresholds"` // comma separated values "x,x". TODO: validation
+	Thresholds string   `json:"thresholds"` // comma separated values "x,x"
 	Colors     []string `json:"colors"`     // array of 3 colors, ie. rgba(50, 172, 45, 0.97)
 
 	// Options. Spark lines.
@@ -82,6 +89,69 @@ func (p *Singlestat) Queries() *[]Query {
 	return &p.queries
 }
 
+var singlestatValueNames = map[string]bool{
+	"min": true, "max": true, "avg": true, "current": true, "total": true,
+	"name": true, "first": true, "delta": true, "diff": true, "range": true,
+}
+
+var fontSizeRegexp = regexp.MustCompile(`^(\d+)%$`)
+
+// Validate checks that panel's options are valid.
+func (p *Singlestat) Validate() error {
+	var errs validate.Errors
+	errs.Merge("", p.generalOptions.Validate())
+
+	if p.ValueName != "" && !singlestatValueNames[p.ValueName] {
+		errs.Add("/valueName", "unknown stat %q", p.ValueName)
+	}
+	validateFontSize(&errs, "/valueFontSize", p.ValueFontSize)
+	validateFontSize(&errs, "/postfixFontSize", p.PostfixFontSize)
+	validateFontSize(&errs, "/prefixFontSize", p.PrefixFontSize)
+
+	if p.Thresholds != "" {
+		var prev float64
+		values := strings.Split(p.Thresholds, ",")
+		if len(values) > 2 {
+			errs.Add("/thresholds", "should have at most 2 values, got %q", p.Thresholds)
+		}
+		for i, v := range values {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				errs.Add("/thresholds", "%q is not a number", v)
+				break
+			}
+			if i > 0 && f < prev {
+				errs.Add("/thresholds", "should be in ascending order, got %q", p.Thresholds)
+			}
+			prev = f
+		}
+	}
+	if len(p.Colors) != 0 && len(p.Colors) != 3 {
+		errs.Add("/colors", "should have 3 colors, got %d", len(p.Colors))
+	}
+	if p.Gauge.Show && p.Gauge.MinValue >= p.Gauge.MaxValue {
+		errs.Add("/gauge/maxValue", "should be greater than minValue %d, got %d", p.Gauge.MinValue, p.Gauge.MaxValue)
+	}
+	if p.ValueMappings.Type > RangeToTextType {
+		errs.Add("/mappingType", "should be %d (value to text) or %d (range to text), got %d", ValueToTextType, RangeToTextType, p.ValueMappings.Type)
+	}
+
+	validateQueries(&errs, p.queries)
+
+	return errs.Err()
+}
+
+func validateFontSize(errs *validate.Errors, path, size string) {
+	if size == "" {
+		return
+	}
+	if m := fontSizeRegexp.FindStringSubmatch(size); m == nil {
+		errs.Add(path, "should be a percentage, got %q", size)
+	} else if n, _ := strconv.Atoi(m[1]); n > 100 {
+		errs.Add(path, "should be 0%%-100%%, got %q", size)
+	}
+}
+
 type ValueMappings struct {
 	Type        valueMappingType     `json:"mappingType"`
 	ValueToText []ValueToTextMapping `json:"valueMaps"`
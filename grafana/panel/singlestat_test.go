@@ -22,6 +22,7 @@ import (
 	"github.com/kr/pretty"
 	"github.com/utilitywarehouse/go-grafana/grafana/panel"
 	jsontools "github.com/utilitywarehouse/go-grafana/pkg/json"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 func TestSinglestat_MarshalJSON(t *testing.T) {
@@ -230,3 +231,34 @@ func TestSinglestat_UnmarshalJSON(t *testing.T) {
 		t.Errorf("Singlestat.UnmarshalJSON: %s", pretty.Diff(expected, &got))
 	}
 }
+
+func TestSinglestat_Validate(t *testing.T) {
+	p := panel.NewSinglestat()
+	p.ValueName = "current"
+	p.ValueFontSize = "80%"
+	p.Thresholds = "50,80"
+	p.Colors = []string{"green", "orange", "red"}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Singlestat.Validate returned error %s", err)
+	}
+
+	p.ValueName = "median"
+	p.ValueFontSize = "120%"
+	p.PrefixFontSize = "12px"
+	p.Thresholds = "80,50"
+	p.Colors = []string{"green", "red"}
+	p.GeneralOptions().Span = 13
+
+	errs, ok := p.Validate().(validate.Errors)
+	if !ok {
+		t.Fatalf("Singlestat.Validate: expected validate.Errors")
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	expected := []string{"/span", "/valueName", "/valueFontSize", "/prefixFontSize", "/thresholds", "/colors"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Singlestat.Validate: got errors of %v, want %v\n%s", got, expected, errs)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panel
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// SnapshotSeries is a time series embedded into panel of a dashboard snapshot,
+// so that the panel is shown without querying its datasource.
+type SnapshotSeries struct {
+	Target     string          `json:"target"` // series name shown in legend
+	Datapoints []SnapshotPoint `json:"datapoints"`
+}
+
+// NewSnapshotSeries creates a new empty SnapshotSeries with given name.
+func NewSnapshotSeries(target string) SnapshotSeries {
+	return SnapshotSeries{
+		Target:     target,
+		Datapoints: []SnapshotPoint{},
+	}
+}
+
+// Add appends a point with given time and value to the series.
+func (s *SnapshotSeries) Add(t time.Time, value float64) {
+	s.Datapoints = append(s.Datapoints, SnapshotPoint{Time: t, Value: null.FloatFrom(value)})
+}
+
+// SnapshotPoint is a point of SnapshotSeries. Points without value are drawn as
+// gaps. It's sent as a pair of value and time in milliseconds since epoch.
+type SnapshotPoint struct {
+	Time  time.Time
+	Value null.Float
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (p SnapshotPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{p.Value, p.Time.UnixNano() / int64(time.Millisecond)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (p *SnapshotPoint) UnmarshalJSON(data []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("snapshot point should be a pair of value and time, got %s", data)
+	}
+
+	var ms int64
+	if err := json.Unmarshal(pair[1], &ms); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(pair[0], &p.Value); err != nil {
+		return err
+	}
+	p.Time = time.Unix(0, ms*int64(time.Millisecond)).UTC()
+	return nil
+}
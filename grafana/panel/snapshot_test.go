@@ -0,0 +1,62 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package panel_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/kr/pretty"
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	jsontools "github.com/utilitywarehouse/go-grafana/pkg/json"
+)
+
+func TestSnapshotSeries_JSON(t *testing.T) {
+	s := panel.NewSnapshotSeries("errors")
+	s.Add(time.Unix(1500000000, 0), 1.5)
+	s.Datapoints = append(s.Datapoints, panel.SnapshotPoint{Time: time.Unix(1500000060, 0)})
+
+	got, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("SnapshotSeries.MarshalJSON returned error %s", err)
+	}
+	expected := []byte(`{"target": "errors", "datapoints": [[1.5, 1500000000000], [null, 1500000060000]]}`)
+	if eq, err := jsontools.BytesEqual(expected, got); err != nil {
+		t.Fatalf("SnapshotSeries.MarshalJSON returned error %s", err)
+	} else if !eq {
+		t.Errorf("SnapshotSeries.MarshalJSON:\ngot %s\nwant %s", got, expected)
+	}
+
+	var decoded panel.SnapshotSeries
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("SnapshotSeries.UnmarshalJSON returned error %s", err)
+	}
+	want := panel.SnapshotSeries{
+		Target: "errors",
+		Datapoints: []panel.SnapshotPoint{
+			{Time: time.Unix(1500000000, 0).UTC(), Value: null.FloatFrom(1.5)},
+			{Time: time.Unix(1500000060, 0).UTC()},
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("SnapshotSeries.UnmarshalJSON: %s", pretty.Diff(want, decoded))
+	}
+
+	if err := json.Unmarshal([]byte(`[1]`), new(panel.SnapshotPoint)); err == nil {
+		t.Errorf("SnapshotPoint.UnmarshalJSON: expected error for a point without time")
+	}
+}
@@ -13,6 +13,8 @@
 
 package panel
 
+import "github.com/utilitywarehouse/go-grafana/pkg/validate"
+
 // textPanelMode is a type of Text panel.
 type textPanelMode string
 
@@ -42,3 +44,16 @@ func NewText(mode textPanelMode) *Text {
 func (p *Text) GeneralOptions() *GeneralOptions {
 	return &p.generalOptions
 }
+
+// Validate checks that panel's options are valid.
+func (p *Text) Validate() error {
+	var errs validate.Errors
+	errs.Merge("", p.generalOptions.Validate())
+	switch p.Mode {
+	case TextPanelHTMLMode, TextPanelMarkdownMode, TextPanelTextMode:
+	default:
+		errs.Add("/mode", "should be %s, %s or %s, got %q", TextPanelHTMLMode, TextPanelMarkdownMode, TextPanelTextMode, p.Mode)
+	}
+
+	return errs.Err()
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/kr/pretty"
 	"github.com/utilitywarehouse/go-grafana/grafana/panel"
 	jsontools "github.com/utilitywarehouse/go-grafana/pkg/json"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
 )
 
 func TestTextPanel_MarshalJSON(t *testing.T) {
@@ -60,3 +61,22 @@ func TestTextPanel_UnmarshalJSON(t *testing.T) {
 		t.Errorf("TextPanel.UnmarshalJSON: %s", pretty.Diff(expected, &got))
 	}
 }
+
+func TestTextPanel_Validate(t *testing.T) {
+	p := panel.NewText("rst")
+	p.GeneralOptions().GridPos = &panel.GridPos{X: 0, Y: 0, W: 25, H: 0}
+	p.GeneralOptions().Links = []panel.PanelLink{*panel.NewPanelLink(panel.PanelLinkAbsolute)}
+
+	errs, ok := p.Validate().(validate.Errors)
+	if !ok {
+		t.Fatalf("TextPanel.Validate: expected validate.Errors")
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	expected := []string{"/gridPos/w", "/gridPos/h", "/links/0/url", "/mode"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("TextPanel.Validate: got errors of %v, want %v\n%s", got, expected, errs)
+	}
+}
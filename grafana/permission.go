@@ -0,0 +1,63 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+// PermissionLevel is a level of access to a dashboard or a folder.
+type PermissionLevel int
+
+const (
+	ViewPermission  PermissionLevel = 1
+	EditPermission  PermissionLevel = 2
+	AdminPermission PermissionLevel = 4
+)
+
+// Permission is an entry of access control list of a dashboard or a folder. It
+// grants a level of access to one subject: all users having a role in the
+// organization, members of a team or a single user.
+type Permission struct {
+	Role       OrgRole         `json:"role,omitempty"`
+	TeamID     TeamID          `json:"teamId,omitempty"`
+	UserID     UserID          `json:"userId,omitempty"`
+	Permission PermissionLevel `json:"permission"`
+
+	// Fields below are set by the API and ignored on update.
+	Team           string `json:"team,omitempty"`
+	UserLogin      string `json:"userLogin,omitempty"`
+	UserEmail      string `json:"userEmail,omitempty"`
+	PermissionName string `json:"permissionName,omitempty"`
+	Inherited      bool   `json:"inherited,omitempty"` // inherited from the parent folder
+}
+
+// NewRolePermission creates a new Permission granting given level of access to
+// all users having given role.
+func NewRolePermission(role OrgRole, level PermissionLevel) *Permission {
+	return &Permission{Role: role, Permission: level}
+}
+
+// NewTeamPermission creates a new Permission granting given level of access to
+// members of team with given id.
+func NewTeamPermission(id TeamID, level PermissionLevel) *Permission {
+	return &Permission{TeamID: id, Permission: level}
+}
+
+// NewUserPermission creates a new Permission granting given level of access to
+// user with given id.
+func NewUserPermission(id UserID, level PermissionLevel) *Permission {
+	return &Permission{UserID: id, Permission: level}
+}
+
+// SameSubject reports whether p and other grant access to the same subject.
+func (p *Permission) SameSubject(other *Permission) bool {
+	return p.Role == other.Role && p.TeamID == other.TeamID && p.UserID == other.UserID
+}
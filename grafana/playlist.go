@@ -0,0 +1,82 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import "strconv"
+
+// PlaylistID is an ID type of Playlist
+type PlaylistID uint
+
+type playlistItemType string
+
+// Types of playlist items. Dashboards are referred by ID in playlists created
+// by Grafana before 9.0 only.
+const (
+	DashboardByIDPlaylistItem  playlistItemType = "dashboard_by_id"
+	DashboardByUIDPlaylistItem playlistItemType = "dashboard_by_uid"
+	DashboardByTagPlaylistItem playlistItemType = "dashboard_by_tag"
+)
+
+// Playlist is a list of dashboards that Grafana shows in turn, e.g. on TV
+// screens. Playlists are identified by ID in Grafana before 9.0 and by UID in
+// the newer versions.
+type Playlist struct {
+	ID       PlaylistID     `json:"id,omitempty"`
+	UID      string         `json:"uid,omitempty"`
+	Name     string         `json:"name"`
+	Interval string         `json:"interval"` // how long each dashboard is shown, e.g. 5m
+	Items    []PlaylistItem `json:"items"`
+}
+
+// NewPlaylist creates a new empty Playlist with given name and interval.
+func NewPlaylist(name, interval string) *Playlist {
+	return &Playlist{
+		Name:     name,
+		Interval: interval,
+		Items:    []PlaylistItem{},
+	}
+}
+
+// AddDashboard appends dashboard with given uid to the playlist.
+func (p *Playlist) AddDashboard(uid string) {
+	p.addItem(DashboardByUIDPlaylistItem, uid)
+}
+
+// AddDashboardByID appends dashboard with given id to the playlist. Use it with
+// Grafana before 9.0 only.
+func (p *Playlist) AddDashboardByID(id DashboardID) {
+	p.addItem(DashboardByIDPlaylistItem, strconv.FormatUint(uint64(id), 10))
+}
+
+// AddTag appends all dashboards with given tag to the playlist.
+func (p *Playlist) AddTag(tag string) {
+	p.addItem(DashboardByTagPlaylistItem, tag)
+}
+
+func (p *Playlist) addItem(t playlistItemType, value string) {
+	p.Items = append(p.Items, PlaylistItem{
+		Type:  t,
+		Value: value,
+		Order: len(p.Items) + 1,
+	})
+}
+
+// PlaylistItem refers to a dashboard or dashboards with a tag shown by
+// playlist.
+type PlaylistItem struct {
+	Type  playlistItemType `json:"type"`
+	Value string           `json:"value"` // dashboard id, uid or tag depending on Type
+	Order int              `json:"order,omitempty"`
+	Title string           `json:"title,omitempty"`
+}
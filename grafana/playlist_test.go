@@ -0,0 +1,41 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPlaylist_AddItems(t *testing.T) {
+	p := NewPlaylist("office", "5m")
+	p.AddDashboard("ops-overview")
+	p.AddDashboardByID(42)
+	p.AddTag("tv")
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Playlist.MarshalJSON returned error %s", err)
+	}
+	expected := []byte(`{"name": "office", "interval": "5m", "items": [
+		{"type": "dashboard_by_uid", "value": "ops-overview", "order": 1},
+		{"type": "dashboard_by_id", "value": "42", "order": 2},
+		{"type": "dashboard_by_tag", "value": "tv", "order": 3}
+	]}`)
+	if eq, err := JSONBytesEqual(expected, b); err != nil {
+		t.Fatalf("Playlist.MarshalJSON returned invalid JSON %s", err)
+	} else if !eq {
+		t.Errorf("Playlist.MarshalJSON:\ngot %s\nwant %s", b, expected)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+// Preferences are UI preferences of an organization, a team or a user. Empty
+// fields mean that the preference is inherited from the enclosing level.
+//
+// Home dashboard is referred by UID since Grafana 9.0, HomeDashboardID is used
+// by earlier versions.
+type Preferences struct {
+	Theme            string      `json:"theme"` // light, dark or system
+	HomeDashboardID  DashboardID `json:"homeDashboardId"`
+	HomeDashboardUID string      `json:"homeDashboardUID,omitempty"`
+	Timezone         string      `json:"timezone"`            // utc, browser or IANA time zone, e.g. Europe/London
+	WeekStart        string      `json:"weekStart,omitempty"` // saturday, sunday or monday
+	Locale           string      `json:"locale,omitempty"`    // e.g. en-GB
+}
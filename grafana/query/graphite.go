@@ -13,22 +13,53 @@
 
 package query
 
+import (
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
 // Graphite is query specific options for Graphite datasource.
 type Graphite struct {
 	Target     string `json:"target"`
 	TargetFull string `json:"targetFull,omitempty"`
 
-	datasource string
+	datasource panel.DatasourceRef
+	refID      string
 }
 
 // NewGraphite creates new instance of Graphite query
 func NewGraphite(datasourceName string) *Graphite {
 	return &Graphite{
-		datasource: datasourceName,
+		datasource: panel.DatasourceRef{Name: datasourceName},
 	}
 }
 
 // Datasource implements panel.Query interface
-func (q *Graphite) Datasource() string {
+func (q *Graphite) Datasource() panel.DatasourceRef {
 	return q.datasource
 }
+
+// SetDatasource implements panel.Query interface
+func (q *Graphite) SetDatasource(ref panel.DatasourceRef) {
+	q.datasource = ref
+}
+
+// RefID implements panel.QueryRef interface
+func (q *Graphite) RefID() string {
+	return q.refID
+}
+
+// SetRefID implements panel.QueryRef interface
+func (q *Graphite) SetRefID(refID string) {
+	q.refID = refID
+}
+
+// Validate checks that query has a target.
+func (q *Graphite) Validate() error {
+	var errs validate.Errors
+	if q.Target == "" {
+		errs.Add("/target", "should not be empty")
+	}
+
+	return errs.Err()
+}
@@ -13,6 +13,11 @@
 
 package query
 
+import (
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+	"github.com/utilitywarehouse/go-grafana/pkg/validate"
+)
+
 // Prometheus is query specific options for Prometheus datasource.
 type Prometheus struct {
 	IntervalFactor uint `json:"intervalFactor"`
@@ -24,17 +29,51 @@ type Prometheus struct {
 	LegendFormat string `json:"legendFormat,omitempty"`
 	Step         uint   `json:"step,omitempty"`
 
-	datasource string
+	datasource panel.DatasourceRef
+	refID      string
 }
 
 // NewPrometheus creates new instance of Prometheus query.
 func NewPrometheus(datasourceName string) *Prometheus {
 	return &Prometheus{
-		datasource: datasourceName,
+		datasource: panel.DatasourceRef{Name: datasourceName},
 	}
 }
 
 // Datasource implements panel.Query interface
-func (q *Prometheus) Datasource() string {
+func (q *Prometheus) Datasource() panel.DatasourceRef {
 	return q.datasource
 }
+
+// SetDatasource implements panel.Query interface
+func (q *Prometheus) SetDatasource(ref panel.DatasourceRef) {
+	q.datasource = ref
+}
+
+// RefID implements panel.QueryRef interface
+func (q *Prometheus) RefID() string {
+	return q.refID
+}
+
+// SetRefID implements panel.QueryRef interface
+func (q *Prometheus) SetRefID(refID string) {
+	q.refID = refID
+}
+
+// Validate checks that query has an expression and known result format.
+func (q *Prometheus) Validate() error {
+	var errs validate.Errors
+	if q.Expression == "" {
+		errs.Add("/expr", "should not be empty")
+	}
+	switch q.Format {
+	case "", "time_series", "table", "heatmap":
+	default:
+		errs.Add("/format", "should be time_series, table or heatmap, got %q", q.Format)
+	}
+	if q.IntervalFactor > 10 {
+		errs.Add("/intervalFactor", "should be 1-10, got %d", q.IntervalFactor)
+	}
+
+	return errs.Err()
+}
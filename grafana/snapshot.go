@@ -0,0 +1,73 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+)
+
+// SnapshotID is an ID type of Snapshot
+type SnapshotID uint64
+
+// Snapshot is a dashboard snapshot, i.e. a copy of dashboard with data of its
+// panels that is shared by a public link. Key of snapshot is a part of the
+// link, and DeleteKey lets anyone who knows it delete the snapshot.
+type Snapshot struct {
+	ID          SnapshotID `json:"id"`
+	Name        string     `json:"name"`
+	Key         string     `json:"key"`
+	OrgID       OrgID      `json:"orgId"`
+	UserID      UserID     `json:"userId"`
+	External    bool       `json:"external"` // stored on external server, e.g. snapshots.raintank.io
+	ExternalURL string     `json:"externalUrl"`
+	Expires     time.Time  `json:"expires"`
+	Created     time.Time  `json:"created"`
+	Updated     time.Time  `json:"updated"`
+}
+
+// SetSnapshotData embeds series into panels of the dashboard, so that it's
+// shown as a snapshot without querying datasources. Series are given by ids of
+// panels, including panels of rows. Panels without ids get no data, they can
+// be given ids by AssignPanelIDs beforehand. It returns an error if there are
+// no panels with some of the ids, data of the other panels is set anyway.
+func (d *Dashboard) SetSnapshotData(data map[uint][]panel.SnapshotSeries) error {
+	found := make(map[uint]bool, len(data))
+	d.walkPanels(func(_ string, p Panel) {
+		opts := p.GeneralOptions()
+		if opts.ID == 0 {
+			return
+		}
+		if series, ok := data[opts.ID]; ok {
+			opts.SnapshotData = series
+			found[opts.ID] = true
+		}
+	})
+
+	var missing []uint
+	for id := range data {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+		return fmt.Errorf("dashboard has no panels with ids %v", missing)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/utilitywarehouse/go-grafana/grafana/panel"
+)
+
+func TestDashboard_SetSnapshotData(t *testing.T) {
+	graph := panel.NewGraph()
+	graph.GeneralOptions().ID = 1
+	nested := panel.NewGraph()
+	nested.GeneralOptions().ID = 2
+	row := NewRowPanel("Row")
+	row.GeneralOptions().ID = 3
+	text := panel.NewText(panel.TextPanelMarkdownMode)
+	row.Panels = Panels{nested, text}
+
+	d := NewDashboard("Incident")
+	d.Panels = Panels{graph, row}
+
+	series := panel.NewSnapshotSeries("errors")
+	series.Add(time.Unix(1500000000, 0), 3)
+	data := map[uint][]panel.SnapshotSeries{
+		1: {series},
+		2: {series},
+		7: {series},
+		5: {series},
+		0: {series},
+	}
+	err := d.SetSnapshotData(data)
+	if err == nil || err.Error() != "dashboard has no panels with ids [0 5 7]" {
+		t.Errorf("Dashboard.SetSnapshotData returned error %v, want error about panels 0, 5 and 7", err)
+	}
+
+	for _, p := range []Panel{graph, nested} {
+		if got := p.GeneralOptions().SnapshotData; !reflect.DeepEqual(got, []panel.SnapshotSeries{series}) {
+			t.Errorf("Dashboard.SetSnapshotData: panel %d has data %v", p.GeneralOptions().ID, got)
+		}
+	}
+	for _, p := range []Panel{row, text} {
+		if got := p.GeneralOptions().SnapshotData; got != nil {
+			t.Errorf("Dashboard.SetSnapshotData: panel %q has data %v", p.GeneralOptions().Title, got)
+		}
+	}
+}
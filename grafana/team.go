@@ -0,0 +1,56 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+// TeamID is an ID type of Team
+type TeamID uint
+
+// Team represents team entity of Grafana. Teams group users of an organization.
+type Team struct {
+	ID          TeamID `json:"id"`
+	OrgID       OrgID  `json:"orgId"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	AvatarURL   string `json:"avatarUrl,omitempty"`
+	MemberCount int    `json:"memberCount"`
+}
+
+// TeamPermission is a permission of a member of a team to manage the team.
+type TeamPermission int
+
+const (
+	MemberTeamPermission TeamPermission = 0
+	AdminTeamPermission  TeamPermission = 4
+)
+
+// TeamMember is a user who is a member of a team.
+type TeamMember struct {
+	OrgID      OrgID          `json:"orgId"`
+	TeamID     TeamID         `json:"teamId"`
+	UserID     UserID         `json:"userId"`
+	Email      string         `json:"email"`
+	Login      string         `json:"login"`
+	Name       string         `json:"name"`
+	AvatarURL  string         `json:"avatarUrl,omitempty"`
+	Labels     []string       `json:"labels,omitempty"` // external auth providers of the user
+	Permission TeamPermission `json:"permission"`
+}
+
+// TeamGroup is an external group, e.g. LDAP or OAuth one, which members are
+// synchronized with members of a team.
+type TeamGroup struct {
+	OrgID   OrgID  `json:"orgId"`
+	TeamID  TeamID `json:"teamId"`
+	GroupID string `json:"groupId"`
+}
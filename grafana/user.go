@@ -0,0 +1,55 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grafana
+
+import "time"
+
+// UserID is an ID type of User
+type UserID uint
+
+// User represents user entity of Grafana.
+type User struct {
+	ID             UserID    `json:"id"`
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	Login          string    `json:"login"`
+	Theme          string    `json:"theme"`
+	OrgID          OrgID     `json:"orgId"`
+	IsGrafanaAdmin bool      `json:"isGrafanaAdmin"`
+	IsDisabled     bool      `json:"isDisabled"`
+	IsExternal     bool      `json:"isExternal"`
+	AuthLabels     []string  `json:"authLabels,omitempty"` // external auth providers of the user
+	AvatarURL      string    `json:"avatarUrl,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	LastSeenAt     time.Time `json:"lastSeenAt"`
+}
+
+// UserOrg is an organization that a user is a member of.
+type UserOrg struct {
+	OrgID OrgID   `json:"orgId"`
+	Name  string  `json:"name"`
+	Role  OrgRole `json:"role"`
+}
+
+// UserAuthToken is a token of a login session of a user.
+type UserAuthToken struct {
+	ID        int64     `json:"id"`
+	IsActive  bool      `json:"isActive"`
+	ClientIP  string    `json:"clientIp"`
+	Browser   string    `json:"browser"`
+	OS        string    `json:"os"`
+	CreatedAt time.Time `json:"createdAt"`
+	SeenAt    time.Time `json:"seenAt"`
+}
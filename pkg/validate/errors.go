@@ -0,0 +1,94 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Error is a validation error of a single value. Path is a JSON pointer to the
+// value relative to validated object, ie. /panels/0/span.
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Errors is a list of validation errors. Validate methods return it to report
+// all problems of an object at once.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add adds an error of the value at given path.
+func (e *Errors) Add(path, format string, args ...interface{}) {
+	*e = append(*e, &Error{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Merge adds an error returned by validation of the value at given path. Paths
+// of nested validation errors are prefixed with the path.
+func (e *Errors) Merge(path string, err error) {
+	switch v := err.(type) {
+	case nil:
+	case Errors:
+		for _, ee := range v {
+			*e = append(*e, &Error{Path: path + ee.Path, Message: ee.Message})
+		}
+	case *Error:
+		*e = append(*e, &Error{Path: path + v.Path, Message: v.Message})
+	default:
+		*e = append(*e, &Error{Path: path, Message: err.Error()})
+	}
+}
+
+// Err returns the errors or nil if there are none. Validate methods should
+// return it instead of Errors to not return non-nil error for empty list.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Path makes JSON pointer of given reference tokens, ie. Path("panels", 0)
+// returns /panels/0.
+func Path(tokens ...interface{}) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		switch v := t.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		default:
+			b.WriteString(pointerEscaper.Replace(fmt.Sprint(v)))
+		}
+	}
+	return b.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
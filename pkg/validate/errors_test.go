@@ -0,0 +1,60 @@
+// Copyright 2017 Sergey Safonov
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestErrors_Merge(t *testing.T) {
+	var nested Errors
+	nested.Add("/span", "should be %d-%d", 1, 12)
+	nested.Add("", "invalid panel")
+
+	var errs Errors
+	errs.Merge(Path("panels", 0), nested.Err())
+	errs.Merge(Path("panels", 1), nil)
+	errs.Merge(Path("panels", 2), errors.New("unknown panel"))
+	errs.Merge("/time", &Error{Path: "/from", Message: "should not be empty"})
+
+	expected := Errors{
+		{Path: "/panels/0/span", Message: "should be 1-12"},
+		{Path: "/panels/0", Message: "invalid panel"},
+		{Path: "/panels/2", Message: "unknown panel"},
+		{Path: "/time/from", Message: "should not be empty"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Errors.Merge: got %v, want %v", errs, expected)
+	}
+
+	msg := "/panels/0/span: should be 1-12; /panels/0: invalid panel; /panels/2: unknown panel; /time/from: should not be empty"
+	if errs.Error() != msg {
+		t.Errorf("Errors.Error: got %q, want %q", errs.Error(), msg)
+	}
+}
+
+func TestErrors_Err(t *testing.T) {
+	var errs Errors
+	if err := errs.Err(); err != nil {
+		t.Errorf("Errors.Err: expected nil, got %v", err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	if got := Path("templating", "list", 1, "a/b~c"); got != "/templating/list/1/a~1b~0c" {
+		t.Errorf("Path: got %s", got)
+	}
+}